@@ -0,0 +1,29 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mgechev/revive/revivelib"
+)
+
+const cacheCommandUsage = "usage: revive cache clean"
+
+// runCacheCommand runs the "revive cache" command with the given arguments.
+func runCacheCommand(args []string) error {
+	if len(args) != 1 || args[0] != "clean" {
+		return errors.New(cacheCommandUsage)
+	}
+
+	dir, err := revivelib.DefaultCacheDir()
+	if err != nil {
+		return fmt.Errorf("cannot find the cache directory: %w", err)
+	}
+
+	if err := revivelib.CleanCache(dir); err != nil {
+		return fmt.Errorf("cannot clean the cache: %w", err)
+	}
+
+	fmt.Printf("Removed the cache in %s\n", dir)
+	return nil
+}
@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"os"
+
+	"github.com/mgechev/revive/config"
+	"github.com/mgechev/revive/internal/lsp"
+	"github.com/mgechev/revive/revivelib"
+)
+
+// runLSPCommand runs the "revive lsp" command, a Language Server Protocol server over stdio.
+func runLSPCommand(args []string, extraRules []revivelib.ExtraRule) error {
+	flags := flag.NewFlagSet("revive lsp", flag.ContinueOnError)
+	lspConfigPath := flags.String("config", "", "path to the configuration file (TOML, YAML or JSON, by extension), defaults to the one of the project or of the user")
+	lspNestedConfig := flags.Bool("nested-config", false, "lint each package with the configuration files of its directory and of its ancestors")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	load := func(root string) (*revivelib.Revive, error) {
+		// looked up on each load, so that a newly created configuration file is found
+		path := *lspConfigPath
+		switch {
+		case path != "":
+		case root != "":
+			path = defaultConfigPathFrom(root)
+		default:
+			path = buildDefaultConfigPath()
+		}
+		conf, err := config.GetConfig(path)
+		if err != nil {
+			return nil, err
+		}
+		revive, err := revivelib.New(conf, false, 0, extraRules...)
+		if err != nil {
+			return nil, err
+		}
+		if *lspNestedConfig {
+			revive.EnableNestedConfig(path)
+		}
+		return revive, nil
+	}
+
+	var configFiles []string
+	if *lspConfigPath != "" {
+		configFiles = append(configFiles, *lspConfigPath)
+	}
+	return lsp.NewServer(load, configFiles...).Serve(context.Background(), os.Stdin, os.Stdout)
+}
@@ -2,15 +2,21 @@
 package cli
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"runtime/debug"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/mgechev/revive/config"
+	"github.com/mgechev/revive/lint"
 	"github.com/mgechev/revive/revivelib"
 	"github.com/mitchellh/go-homedir"
 	"github.com/spf13/afero"
@@ -32,14 +38,157 @@ func fail(err string) {
 
 // RunRevive runs the CLI for revive.
 func RunRevive(extraRules ...revivelib.ExtraRule) {
+	if len(os.Args) > 1 && os.Args[1] == "cache" {
+		if err := runCacheCommand(os.Args[2:]); err != nil {
+			fail(err.Error())
+		}
+		os.Exit(0)
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "lsp" {
+		if err := runLSPCommand(os.Args[2:], extraRules); err != nil {
+			fail(err.Error())
+		}
+		os.Exit(0)
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "rules" {
+		if err := runRulesCommand(os.Stdout, os.Args[2:], extraRules); err != nil {
+			fail(err.Error())
+		}
+		os.Exit(0)
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "explain" {
+		if err := runExplainCommand(os.Stdout, os.Args[2:], extraRules); err != nil {
+			fail(err.Error())
+		}
+		os.Exit(0)
+	}
+
 	// move parsing flags outside of init() otherwise tests dont works properly
 	// more info: https://github.com/golang/go/issues/46869#issuecomment-865695953
 	initConfig()
-	conf, err := config.GetConfig(configPath)
+	if watch {
+		if err := runWatch(extraRules); err != nil {
+			fail(err.Error())
+		}
+		os.Exit(0)
+	}
+
+	if !statsFormats[statsFormat] {
+		fail(fmt.Sprintf("unknown statistics format %q, use table or json", statsFormat))
+	}
+	stopProfiling, err := startProfiling(cpuProfile, memProfile)
 	if err != nil {
 		fail(err.Error())
 	}
 
+	revive, baseline, err := loadRevive(extraRules)
+	if err != nil {
+		fail(err.Error())
+	}
+
+	// finish writes the statistics and the profiles, once linting is over
+	finish := func() {
+		if err := stopProfiling(); err != nil {
+			fail(err.Error())
+		}
+		if showStats {
+			if err := writeStats(os.Stderr, revive.Stats(), statsFormat); err != nil {
+				fail(err.Error())
+			}
+		}
+	}
+
+	var failures <-chan lint.Failure
+	if stdin {
+		failures, err = lintStdin(revive)
+	} else {
+		failures, err = revive.Lint(lintPatterns()...)
+	}
+	if err != nil {
+		fail(err.Error())
+	}
+
+	if fix || fixDryRun {
+		failures, err = revive.Fix(failures, fixDryRun, os.Stdout)
+		if err != nil {
+			fail(err.Error())
+		}
+	}
+
+	if writeBaselinePath != "" {
+		count, err := revive.WriteBaseline(writeBaselinePath, failures)
+		if err != nil {
+			fail(err.Error())
+		}
+		fmt.Fprintf(os.Stderr, "Wrote a baseline of %d failures to %s\n", count, writeBaselinePath)
+		finish()
+		os.Exit(0)
+	}
+
+	output, exitCode, err := revive.Format(formatterName, failures)
+	if err != nil {
+		fail(err.Error())
+	}
+
+	if output != "" {
+		// in dry-run mode, the standard output is the diff of the fixes
+		if fixDryRun {
+			fmt.Fprintln(os.Stderr, output)
+		} else {
+			fmt.Println(output)
+		}
+	}
+
+	if baseline != nil {
+		for _, entry := range baseline.Stale() {
+			fmt.Fprintf(os.Stderr, "%s: stale entry, %d failure(s) fixed in %s: %s (%s)\n", baselinePath, entry.Count, entry.File, entry.Message, entry.Rule)
+		}
+	}
+
+	excludeIssues := revive.ExcludeIssues()
+	paths := make([]string, 0, len(excludeIssues))
+	for path := range excludeIssues {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths) // the configuration given with -config first
+	for _, path := range paths {
+		prefix := ""
+		if path != "" {
+			prefix = path + ": "
+		}
+		for i := range excludeIssues[path] {
+			exclusion := &excludeIssues[path][i]
+			fmt.Fprintf(os.Stderr, "%sexclude-issue #%d (%s) suppressed %d failure(s)\n", prefix, i+1, exclusion, exclusion.Suppressed())
+		}
+	}
+
+	finish()
+	os.Exit(exitCode)
+}
+
+// loadRevive reads the configuration and returns the linter set up as the flags say,
+// with the baseline it uses, if any.
+func loadRevive(extraRules []revivelib.ExtraRule) (*revivelib.Revive, *revivelib.Baseline, error) {
+	conf, err := config.GetConfig(configPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	if buildTags != "" {
+		conf.Build.Tags = strings.Split(buildTags, ",")
+	}
+	if buildGOOS != "" {
+		conf.Build.GOOS = strings.Split(buildGOOS, ",")
+	}
+	if buildGOARCH != "" {
+		conf.Build.GOARCH = strings.Split(buildGOARCH, ",")
+	}
+	if buildMatrix {
+		conf.Build.Matrix = true
+	}
+
 	revive, err := revivelib.New(
 		conf,
 		setExitStatus,
@@ -47,9 +196,66 @@ func RunRevive(extraRules ...revivelib.ExtraRule) {
 		extraRules...,
 	)
 	if err != nil {
-		fail(err.Error())
+		return nil, nil, err
+	}
+
+	if nestedConfig {
+		revive.EnableNestedConfig(configPath)
+	}
+
+	// the packages replayed from the cache would be missing from the statistics
+	if useCache && !showStats {
+		if dir, err := revivelib.DefaultCacheDir(); err == nil {
+			revive.EnableCache(dir)
+		}
 	}
 
+	if newFromRev != "" && newFromPatch != "" {
+		return nil, nil, errors.New("-new-from-rev and -new-from-patch cannot be used together")
+	}
+
+	if newFromRev != "" {
+		if err := revive.NewFromRev(newFromRev); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if newFromPatch != "" {
+		patch, err := os.ReadFile(newFromPatch)
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot read the patch: %w", err)
+		}
+		if err := revive.NewFromPatch(patch); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var baseline *revivelib.Baseline
+	if baselinePath != "" && writeBaselinePath == "" {
+		baseline, err = revivelib.ReadBaseline(baselinePath)
+		if err != nil {
+			return nil, nil, err
+		}
+		revive.SetBaseline(baseline)
+	}
+
+	if showStats {
+		revive.EnableStats()
+	}
+
+	if sortOrder != "" {
+		order, err := revivelib.ParseSortOrder(sortOrder)
+		if err != nil {
+			return nil, nil, err
+		}
+		revive.SetSortOrder(order)
+	}
+
+	return revive, baseline, nil
+}
+
+// lintPatterns returns the patterns of the files and packages to lint given on the command line.
+func lintPatterns() []*revivelib.LintPattern {
 	files := flag.Args()
 	packages := []*revivelib.LintPattern{}
 
@@ -61,30 +267,56 @@ func RunRevive(extraRules ...revivelib.ExtraRule) {
 		packages = append(packages, revivelib.Exclude(file))
 	}
 
-	failures, err := revive.Lint(packages...)
-	if err != nil {
-		fail(err.Error())
-	}
+	return packages
+}
 
-	output, exitCode, err := revive.Format(formatterName, failures)
-	if err != nil {
-		fail(err.Error())
+// lintStdin lints the content of the standard input as the file given with -stdin-filename.
+func lintStdin(revive *revivelib.Revive) (<-chan lint.Failure, error) {
+	switch {
+	case stdinFilename == "":
+		return nil, errors.New("-stdin requires -stdin-filename")
+	case flag.NArg() > 0:
+		return nil, errors.New("-stdin cannot be used with files or packages to lint")
+	case fix || fixDryRun:
+		return nil, errors.New("-stdin cannot be used with -fix or -fix-dry-run")
 	}
 
-	if output != "" {
-		fmt.Println(output)
+	content, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the standard input: %w", err)
 	}
-
-	os.Exit(exitCode)
+	return revive.LintBuffer(context.Background(), stdinFilename, content)
 }
 
 var (
-	configPath      string
-	excludePatterns revivelib.ArrayFlags
-	formatterName   string
-	versionFlag     bool
-	setExitStatus   bool
-	maxOpenFiles    int
+	configPath        string
+	excludePatterns   revivelib.ArrayFlags
+	formatterName     string
+	versionFlag       bool
+	setExitStatus     bool
+	maxOpenFiles      int
+	fix               bool
+	fixDryRun         bool
+	useCache          bool
+	newFromRev        string
+	newFromPatch      string
+	baselinePath      string
+	writeBaselinePath string
+	sortOrder         string
+	nestedConfig      bool
+	buildTags         string
+	buildGOOS         string
+	buildGOARCH       string
+	buildMatrix       bool
+	stdin             bool
+	stdinFilename     string
+	watch             bool
+	watchDebounce     time.Duration
+	watchClear        bool
+	showStats         bool
+	statsFormat       string
+	cpuProfile        string
+	memProfile        string
 )
 
 var originalUsage = flag.Usage
@@ -110,24 +342,50 @@ Example:
 }
 
 func buildDefaultConfigPath() string {
-	var result string
-	var homeDirFile string
-	configFileName := "revive.toml"
-	configDirFile := filepath.Join(os.Getenv("XDG_CONFIG_HOME"), configFileName)
+	wd, _ := os.Getwd() // empty on error, i.e. no project configuration
+	return defaultConfigPathFrom(wd)
+}
 
+// defaultConfigPathFrom returns the configuration file of the project of dir,
+// otherwise of $XDG_CONFIG_HOME or of $HOME, if any.
+func defaultConfigPathFrom(dir string) string {
+	if projectFile := projectConfigPath(dir); projectFile != "" {
+		return projectFile
+	}
+	if configDirFile := config.FindConfigFileIn(AppFs, os.Getenv("XDG_CONFIG_HOME")); configDirFile != "" {
+		return configDirFile
+	}
 	if homeDir, err := homedir.Dir(); err == nil {
-		homeDirFile = filepath.Join(homeDir, configFileName)
+		return config.FindConfigFileIn(AppFs, homeDir)
 	}
+	return ""
+}
 
-	if fileExist(configDirFile) {
-		result = configDirFile
-	} else if fileExist(homeDirFile) {
-		result = homeDirFile
-	} else {
-		result = ""
+// projectConfigPath returns the configuration file of dir or of its ancestors,
+// up to the root of the repository (i.e. the directory containing .git), if any.
+// Outside a repository, only dir is looked up.
+func projectConfigPath(dir string) string {
+	if dir == "" {
+		return ""
 	}
 
-	return result
+	dirs := []string{dir}
+	for !fileExist(filepath.Join(dir, ".git")) {
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			dirs = dirs[:1] // not in a repository
+			break
+		}
+		dir = parent
+		dirs = append(dirs, dir)
+	}
+
+	for _, dir := range dirs {
+		if path := config.FindConfigFileIn(AppFs, dir); path != "" {
+			return path
+		}
+	}
+	return ""
 }
 
 func initConfig() {
@@ -143,12 +401,34 @@ func initConfig() {
 
 	// command line help strings
 	const (
-		configUsage       = "path to the configuration TOML file, defaults to $XDG_CONFIG_HOME/revive.toml or $HOME/revive.toml, if present (i.e. -config myconf.toml)"
-		excludeUsage      = "list of globs which specify files to be excluded (i.e. -exclude foo/...)"
-		formatterUsage    = "formatter to be used for the output (i.e. -formatter stylish)"
-		versionUsage      = "get revive version"
-		exitStatusUsage   = "set exit status to 1 if any issues are found, overwrites errorCode and warningCode in config"
-		maxOpenFilesUsage = "maximum number of open files at the same time"
+		configUsage        = "path to the configuration file (TOML, YAML or JSON, by extension), defaults to the revive.toml, .revive.yaml, .revive.yml or .revive.json file of the project, of $XDG_CONFIG_HOME or of $HOME, if present (i.e. -config myconf.toml)"
+		excludeUsage       = "list of globs which specify files to be excluded (i.e. -exclude foo/...)"
+		formatterUsage     = "formatter to be used for the output (i.e. -formatter stylish)"
+		versionUsage       = "get revive version"
+		exitStatusUsage    = "set exit status to 1 if any issues are found, overwrites errorCode and warningCode in config"
+		maxOpenFilesUsage  = "maximum number of open files at the same time"
+		fixUsage           = "apply the suggested fixes and report only the failures that could not be fixed"
+		fixDryRunUsage     = "print the diff of the suggested fixes instead of applying them, the failures are then reported on the standard error"
+		cacheUsage         = "replay the results of unchanged packages from the cache in $XDG_CACHE_HOME/revive, disabled by -stats, use \"revive cache clean\" to empty it"
+		newFromRevUsage    = "report only the failures on lines added or changed since the given git revision (i.e. -new-from-rev HEAD~1)"
+		newFromPatchUsage  = "report only the failures on lines added or changed by the given unified diff file (i.e. -new-from-patch changes.patch)"
+		baselineUsage      = "hide the failures recorded in the given baseline file, and report its stale entries (i.e. -baseline baseline.json)"
+		nestedConfigUsage  = "merge the configuration files of the directory of each package, and of its ancestors up to the repository root, on top of the configuration"
+		sortUsage          = "order of the failures: position, severity, rule or none, defaults to position except for streaming formatters like ndjson (i.e. -sort severity)"
+		writeBaselineUsage = "record the current failures in the given baseline file instead of reporting them (i.e. -write-baseline baseline.json)"
+		tagsUsage          = "comma-separated list of build tags, only the files satisfying the build constraints are linted (i.e. -tags integration,e2e)"
+		goosUsage          = "comma-separated list of target operating systems, only the files satisfying the build constraints are linted (i.e. -goos linux)"
+		goarchUsage        = "comma-separated list of target architectures, only the files satisfying the build constraints are linted (i.e. -goarch arm64)"
+		buildMatrixUsage   = "lint each combination of -goos and -goarch separately, reporting the failures found in several combinations once"
+		stdinUsage         = "lint the content of the standard input as the file given with -stdin-filename, e.g. an unsaved editor buffer"
+		stdinFilenameUsage = "path of the file whose content is read from the standard input with -stdin, its package is read from disk (i.e. -stdin-filename pkg/file.go)"
+		watchUsage         = "lint again the packages whose files change and the new ones, and all of them when a configuration file changes, until interrupted"
+		watchDebounceUsage = "with -watch, how long the files must be left unchanged before linting them again"
+		watchClearUsage    = "with -watch, clear the screen of the terminal before each output"
+		statsUsage         = "write on the standard error the time spent in each rule, with the number of files and failures, and the time spent parsing and type checking each package, without the cache"
+		statsFormatUsage   = "format of the statistics written with -stats: table or json"
+		cpuProfileUsage    = "write a CPU profile of the run to the given file"
+		memProfileUsage    = "write a memory profile at the end of the run to the given file"
 	)
 
 	defaultConfigPath := buildDefaultConfigPath()
@@ -159,6 +439,28 @@ func initConfig() {
 	flag.BoolVar(&versionFlag, "version", false, versionUsage)
 	flag.BoolVar(&setExitStatus, "set_exit_status", false, exitStatusUsage)
 	flag.IntVar(&maxOpenFiles, "max_open_files", 0, maxOpenFilesUsage)
+	flag.BoolVar(&fix, "fix", false, fixUsage)
+	flag.BoolVar(&fixDryRun, "fix-dry-run", false, fixDryRunUsage)
+	flag.BoolVar(&useCache, "cache", true, cacheUsage)
+	flag.StringVar(&newFromRev, "new-from-rev", "", newFromRevUsage)
+	flag.StringVar(&newFromPatch, "new-from-patch", "", newFromPatchUsage)
+	flag.StringVar(&baselinePath, "baseline", "", baselineUsage)
+	flag.StringVar(&writeBaselinePath, "write-baseline", "", writeBaselineUsage)
+	flag.StringVar(&sortOrder, "sort", "", sortUsage)
+	flag.BoolVar(&nestedConfig, "nested-config", false, nestedConfigUsage)
+	flag.StringVar(&buildTags, "tags", "", tagsUsage)
+	flag.StringVar(&buildGOOS, "goos", "", goosUsage)
+	flag.StringVar(&buildGOARCH, "goarch", "", goarchUsage)
+	flag.BoolVar(&buildMatrix, "build-matrix", false, buildMatrixUsage)
+	flag.BoolVar(&stdin, "stdin", false, stdinUsage)
+	flag.StringVar(&stdinFilename, "stdin-filename", "", stdinFilenameUsage)
+	flag.BoolVar(&watch, "watch", false, watchUsage)
+	flag.DurationVar(&watchDebounce, "watch-debounce", 300*time.Millisecond, watchDebounceUsage)
+	flag.BoolVar(&watchClear, "watch-clear", false, watchClearUsage)
+	flag.BoolVar(&showStats, "stats", false, statsUsage)
+	flag.StringVar(&statsFormat, "stats-format", "table", statsFormatUsage)
+	flag.StringVar(&cpuProfile, "cpuprofile", "", cpuProfileUsage)
+	flag.StringVar(&memProfile, "memprofile", "", memProfileUsage)
 	flag.Parse()
 
 	// Output build info (version, commit, date and builtBy)
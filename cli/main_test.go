@@ -2,6 +2,7 @@ package cli
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/spf13/afero"
@@ -81,3 +82,89 @@ func TestXDGConfigDirNoFile(t *testing.T) {
 		t.Errorf("got %q, wanted %q", got, want)
 	}
 }
+
+func TestYAMLConfigInXDGConfigDir(t *testing.T) {
+	AppFs = afero.NewMemMapFs()
+	t.Cleanup(func() {
+		AppFs = afero.NewMemMapFs()
+	})
+
+	xdgDirPath := "/tmp-iofs/xdg/config"
+	AppFs.MkdirAll(xdgDirPath, 0755)
+
+	afero.WriteFile(AppFs, xdgDirPath+"/.revive.yaml", []byte("\n"), 0644)
+	t.Setenv("XDG_CONFIG_HOME", xdgDirPath)
+
+	got := buildDefaultConfigPath()
+	want := xdgDirPath + "/.revive.yaml"
+
+	if got != want {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+}
+
+func TestProjectConfigIsPreferredFirst(t *testing.T) {
+	t.Cleanup(func() {
+		AppFs = afero.NewMemMapFs()
+	})
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	root := filepath.Dir(wd)
+	AppFs.MkdirAll(root+"/.git", 0755)
+	afero.WriteFile(AppFs, root+"/.revive.json", []byte("{}"), 0644)
+
+	xdgDirPath := "/tmp-iofs/xdg/config"
+	AppFs.MkdirAll(xdgDirPath, 0755)
+	afero.WriteFile(AppFs, xdgDirPath+"/revive.toml", []byte("\n"), 0644)
+	t.Setenv("XDG_CONFIG_HOME", xdgDirPath)
+
+	got := buildDefaultConfigPath()
+	want := root + "/.revive.json"
+
+	if got != want {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+}
+
+func TestProjectConfigOutsideRepository(t *testing.T) {
+	t.Cleanup(func() {
+		AppFs = afero.NewMemMapFs()
+	})
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// without .git, the ancestors of the working directory are not looked up
+	afero.WriteFile(AppFs, filepath.Dir(wd)+"/revive.toml", []byte("\n"), 0644)
+	afero.WriteFile(AppFs, wd+"/.revive.yml", []byte("\n"), 0644)
+
+	got := buildDefaultConfigPath()
+	want := wd + "/.revive.yml"
+
+	if got != want {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+}
+
+func TestProjectConfigOfDirectory(t *testing.T) {
+	t.Cleanup(func() {
+		AppFs = afero.NewMemMapFs()
+	})
+
+	// e.g. the root of the workspace of the language server, not the working directory
+	root := "/tmp-iofs/workspace"
+	AppFs.MkdirAll(root+"/.git", 0755)
+	AppFs.MkdirAll(root+"/cmd/app", 0755)
+	afero.WriteFile(AppFs, root+"/revive.toml", []byte("\n"), 0644)
+
+	got := defaultConfigPathFrom(root + "/cmd/app")
+	want := root + "/revive.toml"
+
+	if got != want {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+}
@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/fatih/color"
+	"github.com/mgechev/revive/config"
+	"github.com/mgechev/revive/lint"
+	"github.com/mgechev/revive/revivelib"
+)
+
+const (
+	rulesCommandUsage   = "usage: revive rules list [-json]"
+	explainCommandUsage = "usage: revive explain <rule>"
+)
+
+// rulesDescriptionsURL documents the rules not implementing lint.RuleMetadata.
+const rulesDescriptionsURL = "https://github.com/mgechev/revive/blob/master/RULES_DESCRIPTIONS.md"
+
+// runRulesCommand runs the "revive rules" command with the given arguments.
+func runRulesCommand(w io.Writer, args []string, extraRules []revivelib.ExtraRule) error {
+	if len(args) == 0 || args[0] != "list" {
+		return errors.New(rulesCommandUsage)
+	}
+	flags := flag.NewFlagSet("revive rules list", flag.ContinueOnError)
+	asJSON := flags.Bool("json", false, "print the rules in JSON")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return errors.New(rulesCommandUsage)
+	}
+
+	rules := config.DescribeRules(ruleInstances(extraRules))
+	if *asJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(rules)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tDEFAULT\tCATEGORY\tDESCRIPTION")
+	for _, rule := range rules {
+		enabled := ""
+		if rule.DefaultEnabled {
+			enabled = "yes"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", rule.Name, enabled, rule.Category, rule.Description)
+	}
+	return tw.Flush()
+}
+
+// runExplainCommand runs the "revive explain" command with the given arguments.
+func runExplainCommand(w io.Writer, args []string, extraRules []revivelib.ExtraRule) error {
+	if len(args) != 1 {
+		return errors.New(explainCommandUsage)
+	}
+	rule, ok := config.DescribeRule(args[0], ruleInstances(extraRules))
+	if !ok {
+		return fmt.Errorf("unknown rule %q, see \"revive rules list\" for the available rules", args[0])
+	}
+
+	fmt.Fprintln(w, color.New(color.Bold).Sprint(rule.Name))
+	if !rule.Documented {
+		fmt.Fprintf(w, "\nThe rule has no description, see %s#%s\n", rulesDescriptionsURL, rule.Name)
+		return nil
+	}
+
+	fmt.Fprintln(w)
+	if rule.Category != "" {
+		fmt.Fprintf(w, "Category: %s\n", rule.Category)
+	}
+	enabled := "no"
+	if rule.DefaultEnabled {
+		enabled = "yes"
+	}
+	fmt.Fprintf(w, "Enabled by default: %s\n", enabled)
+	if rule.MinGoVersion != "" {
+		fmt.Fprintf(w, "Minimum Go version: %s\n", rule.MinGoVersion)
+	}
+	fmt.Fprintf(w, "\n%s\n", rule.Description)
+	if rule.Rationale != "" {
+		fmt.Fprintf(w, "\n%s\n", rule.Rationale)
+	}
+
+	if len(rule.Arguments) > 0 {
+		fmt.Fprintln(w, "\nArguments:")
+		for _, arg := range rule.Arguments {
+			fmt.Fprintf(w, "  %s (%s): %s\n", arg.Name, arg.Type, arg.Description)
+		}
+	}
+
+	for _, example := range rule.Examples {
+		if example.Bad != "" {
+			fmt.Fprintf(w, "\n%s\n%s", color.RedString("Bad:"), indent(example.Bad))
+		}
+		if example.Good != "" {
+			fmt.Fprintf(w, "\n%s\n%s", color.GreenString("Good:"), indent(example.Good))
+		}
+	}
+	return nil
+}
+
+// ruleInstances returns the rules of the extra rules.
+func ruleInstances(extraRules []revivelib.ExtraRule) []lint.Rule {
+	rules := make([]lint.Rule, 0, len(extraRules))
+	for _, extraRule := range extraRules {
+		rules = append(rules, extraRule.Rule)
+	}
+	return rules
+}
+
+// indent indents the lines of code, ending it with a newline.
+func indent(code string) string {
+	var b strings.Builder
+	for _, line := range strings.Split(strings.TrimRight(code, "\n"), "\n") {
+		if line != "" {
+			b.WriteString("    ")
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mgechev/revive/config"
+	"github.com/mgechev/revive/lint"
+	"github.com/mgechev/revive/revivelib"
+)
+
+type undocumentedRule struct{}
+
+func (undocumentedRule) Name() string { return "undocumented" }
+
+func (undocumentedRule) Apply(*lint.File, lint.Arguments) []lint.Failure { return nil }
+
+func TestRulesCommand(t *testing.T) {
+	var out bytes.Buffer
+	if err := runRulesCommand(&out, []string{"list", "-json"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	var rules []config.RuleDescription
+	if err := json.Unmarshal(out.Bytes(), &rules); err != nil {
+		t.Fatalf("invalid JSON %q: %v", out.String(), err)
+	}
+	if len(rules) == 0 {
+		t.Fatal("no rule listed")
+	}
+
+	out.Reset()
+	if err := runRulesCommand(&out, []string{"list"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := out.String(); !strings.HasPrefix(got, "NAME") || !strings.Contains(got, "increment-decrement") {
+		t.Errorf("got the list %q", got)
+	}
+
+	if err := runRulesCommand(&out, []string{"show"}, nil); err == nil || err.Error() != rulesCommandUsage {
+		t.Errorf("got error %v for an unknown subcommand, want the usage", err)
+	}
+}
+
+func TestExplainCommand(t *testing.T) {
+	var out bytes.Buffer
+	if err := runExplainCommand(&out, []string{"increment-decrement"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"Category: style", "Bad:\n    count += 1\n", "Good:\n    count++\n"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("explanation %q does not contain %q", out.String(), want)
+		}
+	}
+
+	out.Reset()
+	extraRules := []revivelib.ExtraRule{{Rule: undocumentedRule{}}}
+	if err := runExplainCommand(&out, []string{"undocumented"}, extraRules); err != nil {
+		t.Fatal(err)
+	}
+	if want := rulesDescriptionsURL + "#undocumented"; !strings.Contains(out.String(), want) {
+		t.Errorf("explanation %q of an undocumented rule does not point to %s", out.String(), want)
+	}
+
+	if err := runExplainCommand(&out, []string{"unknown"}, nil); err == nil {
+		t.Error("no error for an unknown rule")
+	}
+}
@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"strconv"
+	"time"
+
+	"github.com/mgechev/revive/lint"
+	"github.com/olekukonko/tablewriter"
+)
+
+// statsFormats are the formats of the statistics.
+var statsFormats = map[string]bool{"table": true, "json": true}
+
+// writeStats writes the statistics in the given format, one of statsFormats.
+// Durations are in nanoseconds in the JSON format.
+func writeStats(w io.Writer, stats *lint.Stats, format string) error {
+	if format == "json" {
+		return json.NewEncoder(w).Encode(struct {
+			Rules    []lint.RuleStats
+			Packages []lint.PackageStats
+		}{stats.Rules(), stats.Packages()})
+	}
+
+	rules := [][]string{}
+	for _, rule := range stats.Rules() {
+		rules = append(rules, []string{rule.Name, formatDuration(rule.Duration), strconv.Itoa(rule.Files), strconv.Itoa(rule.Failures)})
+	}
+	packages := [][]string{}
+	for _, pkg := range stats.Packages() {
+		packages = append(packages, []string{pkg.Dir, pkg.Name, strconv.Itoa(pkg.Files), formatDuration(pkg.Parse), formatDuration(pkg.TypeCheck)})
+	}
+
+	_, err := fmt.Fprintf(w, "%s\n%s",
+		statsTable([]string{"Rule", "Time", "Files", "Failures"}, rules),
+		statsTable([]string{"Directory", "Package", "Files", "Parse", "Type check"}, packages))
+	return err
+}
+
+func statsTable(header []string, rows [][]string) string {
+	buf := new(bytes.Buffer)
+	table := tablewriter.NewWriter(buf)
+	table.SetHeader(header)
+	table.SetAutoWrapText(false)
+	table.SetAutoFormatHeaders(false)
+	table.AppendBulk(rows)
+	table.Render()
+	return buf.String()
+}
+
+func formatDuration(d time.Duration) string {
+	return d.Round(time.Microsecond).String()
+}
+
+// startProfiling starts the CPU profiling into cpuProfile, if set.
+// The returned function stops it and writes the memory profile into memProfile, if set.
+func startProfiling(cpuProfile, memProfile string) (stop func() error, err error) {
+	var cpuFile *os.File
+	if cpuProfile != "" {
+		cpuFile, err = os.Create(cpuProfile)
+		if err != nil {
+			return nil, fmt.Errorf("cannot create the CPU profile: %w", err)
+		}
+		if err := pprof.StartCPUProfile(cpuFile); err != nil {
+			cpuFile.Close()
+			return nil, fmt.Errorf("cannot start the CPU profiling: %w", err)
+		}
+	}
+
+	return func() error {
+		if cpuFile != nil {
+			pprof.StopCPUProfile()
+			if err := cpuFile.Close(); err != nil {
+				return fmt.Errorf("cannot write the CPU profile: %w", err)
+			}
+		}
+		if memProfile == "" {
+			return nil
+		}
+
+		memFile, err := os.Create(memProfile)
+		if err != nil {
+			return fmt.Errorf("cannot create the memory profile: %w", err)
+		}
+		defer memFile.Close()
+		runtime.GC() // get up-to-date statistics
+		if err := pprof.WriteHeapProfile(memFile); err != nil {
+			return fmt.Errorf("cannot write the memory profile: %w", err)
+		}
+		return memFile.Close()
+	}, nil
+}
@@ -0,0 +1,368 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mgechev/revive/config"
+	"github.com/mgechev/revive/lint"
+	"github.com/mgechev/revive/revivelib"
+)
+
+// watchInterval is the interval between two scans of the watched files.
+var watchInterval = 200 * time.Millisecond
+
+// clearScreen moves the cursor of the terminal home and clears the screen.
+const clearScreen = "\033[H\033[2J"
+
+// runWatch lints the packages given on the command line, then lints them again when their files change.
+func runWatch(extraRules []revivelib.ExtraRule) error {
+	switch {
+	case stdin:
+		return errors.New("-watch cannot be used with -stdin")
+	case fix || fixDryRun:
+		return errors.New("-watch cannot be used with -fix or -fix-dry-run")
+	case writeBaselinePath != "":
+		return errors.New("-watch cannot be used with -write-baseline")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	w := &watcher{
+		load: func() (*revivelib.Revive, error) {
+			revive, _, err := loadRevive(extraRules)
+			return revive, err
+		},
+		patterns:   lintPatterns(),
+		configPath: configPath,
+		formatter:  formatterName,
+		debounce:   watchDebounce,
+		clear:      watchClear,
+		out:        os.Stdout,
+		errOut:     os.Stderr,
+	}
+	return w.run(ctx)
+}
+
+// fileStamp identifies the version of a file or of the entries of a directory.
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+	dir     bool
+}
+
+// watcher lints packages again when their files change.
+type watcher struct {
+	// load returns the linter, it is called again when a configuration file changes
+	load     func() (*revivelib.Revive, error)
+	patterns []*revivelib.LintPattern
+	// configPath is the configuration file given on the command line, if any,
+	// watched besides the configuration files of the packages and of their ancestors
+	configPath string
+	formatter  string
+	// debounce is how long the files must be left unchanged before linting them
+	debounce time.Duration
+	// clear is whether the screen is cleared before each output
+	clear  bool
+	out    io.Writer
+	errOut io.Writer
+
+	revive *revivelib.Revive
+	// packages are the files of the linted packages by directory
+	packages map[string][]string
+	// failures are the failures of the linted packages by directory
+	failures map[string][]lint.Failure
+	// stamps are the stamps of the watched files, i.e. the directories of the patterns,
+	// the Go files of the package directories and the configuration files
+	stamps map[string]fileStamp
+}
+
+// run lints the packages and lints them again on changes until ctx is done.
+func (w *watcher) run(ctx context.Context) error {
+	revive, err := w.load()
+	if err != nil {
+		return err
+	}
+	w.revive = revive
+	w.packages = map[string][]string{}
+	w.failures = map[string][]lint.Failure{}
+	if err := w.lint(ctx, nil); err != nil {
+		return err
+	}
+	w.stamps = w.scan()
+	w.render()
+
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+
+	changedDirs := map[string]bool{}
+	configChanged := false
+	var lastChange time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case now := <-ticker.C:
+			stamps := w.scan()
+			dirs, config := w.changes(stamps)
+			w.stamps = stamps
+			if len(dirs) > 0 || config {
+				for _, dir := range dirs {
+					changedDirs[dir] = true
+				}
+				configChanged = configChanged || config
+				lastChange = now
+				continue
+			}
+			if len(changedDirs) == 0 && !configChanged || now.Sub(lastChange) < w.debounce {
+				continue
+			}
+
+			if err := w.update(ctx, changedDirs, configChanged); err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				fmt.Fprintln(w.errOut, err)
+			} else {
+				w.render()
+			}
+			changedDirs = map[string]bool{}
+			configChanged = false
+			// the package directories may have changed
+			w.stamps = w.scan()
+		}
+	}
+}
+
+// update lints again the packages of the changed directories, or all the packages
+// with the reloaded configuration, and thus new nested configurations, if it changed.
+func (w *watcher) update(ctx context.Context, changedDirs map[string]bool, configChanged bool) error {
+	if configChanged {
+		revive, err := w.load()
+		if err != nil {
+			return fmt.Errorf("cannot reload the configuration: %w", err)
+		}
+		w.revive = revive
+		return w.lint(ctx, nil)
+	}
+	return w.lint(ctx, changedDirs)
+}
+
+// lint resolves the packages again and lints those in dirs,
+// those whose files changed and the new ones, or all of them if dirs is nil.
+func (w *watcher) lint(ctx context.Context, dirs map[string]bool) error {
+	resolved, err := w.revive.Packages(w.patterns...)
+	if err != nil {
+		return err
+	}
+
+	packages := map[string][]string{}
+	var toLint [][]string
+	for _, files := range resolved {
+		if len(files) == 0 {
+			continue
+		}
+		dir := filepath.Dir(files[0])
+		packages[dir] = files
+		old, known := w.packages[dir]
+		if dirs == nil || dirs[dir] || !known || strings.Join(old, "\x00") != strings.Join(files, "\x00") {
+			toLint = append(toLint, files)
+		}
+	}
+	for dir := range w.failures {
+		if _, ok := packages[dir]; !ok {
+			delete(w.failures, dir)
+		}
+	}
+	w.packages = packages
+
+	failures, err := w.revive.LintPackages(ctx, toLint)
+	if err != nil {
+		return err
+	}
+	for _, files := range toLint {
+		w.failures[filepath.Dir(files[0])] = nil
+	}
+	for failure := range failures {
+		dir := filepath.Dir(failure.GetFilename())
+		w.failures[dir] = append(w.failures[dir], failure)
+	}
+	return ctx.Err()
+}
+
+// scan returns the stamps of the watched files.
+func (w *watcher) scan() map[string]fileStamp {
+	stamps := map[string]fileStamp{}
+	stamp := func(path string) {
+		if info, err := os.Stat(path); err == nil {
+			stamps[path] = fileStamp{modTime: info.ModTime(), size: info.Size(), dir: info.IsDir()}
+		}
+	}
+
+	// the entries of the directories change when a package is added or removed
+	for _, dir := range w.patternDirs() {
+		stamp(dir)
+	}
+	configDirs := map[string]bool{}
+	for dir := range w.packages {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".go") {
+				stamp(filepath.Join(dir, entry.Name()))
+			}
+		}
+		addConfigDirs(configDirs, dir)
+	}
+	for dir := range configDirs {
+		for _, name := range config.FileNames() {
+			stamp(filepath.Join(dir, name))
+		}
+	}
+	if w.configPath != "" {
+		stamp(w.configPath)
+	}
+	return stamps
+}
+
+// patternDirs returns the directories matched by the patterns to lint,
+// i.e. those walked by the patterns ending with "..." and the directories given as patterns.
+func (w *watcher) patternDirs() []string {
+	var dirs []string
+	addPattern := func(pattern string) {
+		root, recursive := strings.CutSuffix(pattern, "...")
+		root = filepath.Clean(root)
+		info, err := os.Stat(root)
+		if err != nil || !info.IsDir() {
+			return // a file, or a directory not created yet
+		}
+		if !recursive {
+			dirs = append(dirs, root)
+			return
+		}
+		filepath.WalkDir(root, func(path string, entry os.DirEntry, err error) error {
+			if err != nil || !entry.IsDir() {
+				return nil
+			}
+			// as when resolving the packages, skip the .foo, _foo and testdata directories
+			if name := entry.Name(); path != root && (strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || name == "testdata") {
+				return filepath.SkipDir
+			}
+			dirs = append(dirs, path)
+			return nil
+		})
+	}
+	var patterns []string
+	for _, pattern := range w.patterns {
+		if !pattern.IsExclude() {
+			patterns = append(patterns, pattern.GetPattern())
+		}
+	}
+	if len(patterns) == 0 {
+		patterns = []string{"."} // as when resolving the packages
+	}
+	for _, pattern := range patterns {
+		addPattern(pattern)
+	}
+	return dirs
+}
+
+// addConfigDirs adds to dirs the directories whose configuration files may apply to the package in dir,
+// i.e. dir and its ancestors up to the root of the repository (see config.Hierarchy).
+func addConfigDirs(dirs map[string]bool, dir string) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return
+	}
+	for !dirs[dir] {
+		dirs[dir] = true
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return
+		}
+		dir = parent
+	}
+}
+
+// changes returns the directories whose Go files or entries changed since the last scan,
+// and whether a configuration file changed.
+func (w *watcher) changes(stamps map[string]fileStamp) (dirs []string, config bool) {
+	changed := func(path string, stamp fileStamp) {
+		switch {
+		case path == w.configPath || isConfigFile(path):
+			config = true
+		case stamp.dir:
+			dirs = append(dirs, path)
+		default:
+			dirs = append(dirs, filepath.Dir(path))
+		}
+	}
+	for path, stamp := range stamps {
+		if old, ok := w.stamps[path]; !ok || !old.modTime.Equal(stamp.modTime) || old.size != stamp.size {
+			changed(path, stamp)
+		}
+	}
+	for path, old := range w.stamps {
+		if _, ok := stamps[path]; !ok {
+			changed(path, old)
+		}
+	}
+	return dirs, config
+}
+
+// isConfigFile returns true if the file at path is named as a configuration file (see config.FileNames).
+func isConfigFile(path string) bool {
+	name := filepath.Base(path)
+	for _, configName := range config.FileNames() {
+		if name == configName {
+			return true
+		}
+	}
+	return false
+}
+
+// render formats the failures of all the packages.
+func (w *watcher) render() {
+	dirs := make([]string, 0, len(w.failures))
+	count := 0
+	for dir, failures := range w.failures {
+		dirs = append(dirs, dir)
+		count += len(failures)
+	}
+	sort.Strings(dirs)
+
+	failures := make(chan lint.Failure, count)
+	for _, dir := range dirs {
+		for _, failure := range w.failures[dir] {
+			failures <- failure
+		}
+	}
+	close(failures)
+	output, _, err := w.revive.Format(w.formatter, failures)
+	if err != nil {
+		fmt.Fprintln(w.errOut, err)
+		return
+	}
+
+	if w.clear {
+		fmt.Fprint(w.out, clearScreen)
+	}
+	if output != "" {
+		fmt.Fprintln(w.out, output)
+	}
+	fmt.Fprintf(w.errOut, "Linted %d package(s) at %s, watching for changes...\n", len(w.packages), time.Now().Format(time.TimeOnly))
+}
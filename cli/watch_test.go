@@ -0,0 +1,178 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mgechev/revive/config"
+	"github.com/mgechev/revive/revivelib"
+)
+
+// syncBuffer is a bytes.Buffer safe for concurrent use.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestWatch(t *testing.T) {
+	defer func(interval time.Duration) { watchInterval = interval }(watchInterval)
+	watchInterval = 10 * time.Millisecond
+
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "revive.toml")
+	writeTestFile(t, configFile, "[rule.increment-decrement]\n")
+	file := filepath.Join(dir, "p.go")
+	writeTestFile(t, file, "package p\n\nvar y int = 0\n\nfunc f(x int) int {\n\tx += 1\n\treturn x\n}\n")
+
+	out, errOut := &syncBuffer{}, &syncBuffer{}
+	w := &watcher{
+		load: func() (*revivelib.Revive, error) {
+			conf, err := config.GetConfig(configFile)
+			if err != nil {
+				return nil, err
+			}
+			return revivelib.New(conf, false, 0)
+		},
+		patterns:   []*revivelib.LintPattern{revivelib.Include(filepath.Join(dir, "..."))},
+		configPath: configFile,
+		formatter:  "plain",
+		debounce:   30 * time.Millisecond,
+		clear:      true,
+		out:        out,
+		errOut:     errOut,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- w.run(ctx) }()
+
+	// lastOutput waits for the given number of outputs and returns the last one
+	lastOutput := func(outputs int) string {
+		t.Helper()
+		for deadline := time.Now().Add(10 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+			if strings.Count(errOut.String(), "watching for changes") >= outputs {
+				screens := strings.Split(out.String(), clearScreen)
+				return screens[len(screens)-1]
+			}
+		}
+		t.Fatalf("timeout waiting for output #%d, got %q", outputs, errOut.String())
+		return ""
+	}
+
+	if got := lastOutput(1); !strings.Contains(got, "should replace x += 1 with x++") {
+		t.Errorf("got output %q, want the failure of the package", got)
+	}
+
+	writeTestFile(t, file, "package p\n\nvar y int = 0\n\nfunc f(x int) int {\n\tx++\n\treturn x\n}\n")
+	if got := lastOutput(2); got != "" {
+		t.Errorf("got output %q after the fix, want none", got)
+	}
+
+	writeTestFile(t, configFile, "[rule.var-declaration]\n")
+	if got := lastOutput(3); !strings.Contains(got, "should drop = 0 from declaration of var y") {
+		t.Errorf("got output %q after the configuration change, want the failure of the new rule", got)
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("run returned %v", err)
+	}
+}
+
+func TestWatchNewPackageAndNestedConfig(t *testing.T) {
+	defer func(interval time.Duration) { watchInterval = interval }(watchInterval)
+	watchInterval = 10 * time.Millisecond
+
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	configFile := filepath.Join(dir, "revive.toml")
+	writeTestFile(t, configFile, "[rule.increment-decrement]\n")
+	writeTestFile(t, filepath.Join(dir, "p.go"), "package p\n")
+
+	out, errOut := &syncBuffer{}, &syncBuffer{}
+	w := &watcher{
+		load: func() (*revivelib.Revive, error) {
+			conf, err := config.GetConfig(configFile)
+			if err != nil {
+				return nil, err
+			}
+			revive, err := revivelib.New(conf, false, 0)
+			if err != nil {
+				return nil, err
+			}
+			revive.EnableNestedConfig(configFile)
+			return revive, nil
+		},
+		patterns:   []*revivelib.LintPattern{revivelib.Include(filepath.Join(dir, "..."))},
+		configPath: configFile,
+		formatter:  "plain",
+		debounce:   30 * time.Millisecond,
+		clear:      true,
+		out:        out,
+		errOut:     errOut,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- w.run(ctx) }()
+
+	lastOutput := func(outputs int) string {
+		t.Helper()
+		for deadline := time.Now().Add(10 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+			if strings.Count(errOut.String(), "watching for changes") >= outputs {
+				screens := strings.Split(out.String(), clearScreen)
+				return screens[len(screens)-1]
+			}
+		}
+		t.Fatalf("timeout waiting for output #%d, got %q", outputs, errOut.String())
+		return ""
+	}
+
+	if got := lastOutput(1); got != "" {
+		t.Errorf("got output %q, want none", got)
+	}
+
+	sub := filepath.Join(dir, "q")
+	if err := os.Mkdir(sub, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeTestFile(t, filepath.Join(sub, "q.go"), "package q\n\nvar y int = 0\n\nfunc f(x int) int {\n\tx += 1\n\treturn x\n}\n")
+	if got := lastOutput(2); !strings.Contains(got, "should replace x += 1 with x++") {
+		t.Errorf("got output %q after the creation of a package, want its failure", got)
+	}
+
+	writeTestFile(t, filepath.Join(sub, "revive.toml"), "[rule.var-declaration]\n")
+	if got := lastOutput(3); !strings.Contains(got, "should drop = 0 from declaration of var y") {
+		t.Errorf("got output %q after the creation of a nested configuration, want the failure of its rule", got)
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("run returned %v", err)
+	}
+}
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"reflect"
 
 	"github.com/BurntSushi/toml"
 
@@ -119,18 +120,10 @@ func getFormatters() map[string]lint.Formatter {
 	return result
 }
 
-// GetLintingRules yields the linting rules that must be applied by the linter
+// GetLintingRules yields the linting rules that must be applied by the linter.
+// It also configures the rules of the overrides of the rules of config.
 func GetLintingRules(config *lint.Config, extraRules []lint.Rule) ([]lint.Rule, error) {
-	rulesMap := map[string]lint.Rule{}
-	for _, r := range allRules {
-		rulesMap[r.Name()] = r
-	}
-	for _, r := range extraRules {
-		if _, ok := rulesMap[r.Name()]; ok {
-			continue
-		}
-		rulesMap[r.Name()] = r
-	}
+	rulesMap := availableRules(extraRules)
 
 	var lintingRules []lint.Rule
 	for name, ruleConfig := range config.Rules {
@@ -144,12 +137,72 @@ func GetLintingRules(config *lint.Config, extraRules []lint.Rule) ([]lint.Rule,
 			continue // skip disabled rules
 		}
 
+		base := r
+		r = cloneRule(base)
+		if r, ok := r.(lint.ConfigurableRule); ok {
+			if err := r.Configure(ruleConfig.Arguments); err != nil {
+				return nil, fmt.Errorf("cannot configure rule: %q: %w", name, err)
+			}
+		}
+		if len(ruleConfig.Override) > 0 {
+			if err := ruleConfig.ConfigureOverrides(func() lint.Rule { return cloneRule(base) }); err != nil {
+				return nil, fmt.Errorf("cannot configure rule: %q: %w", name, err)
+			}
+			config.Rules[name] = ruleConfig
+		}
+
 		lintingRules = append(lintingRules, r)
 	}
 
 	return lintingRules, nil
 }
 
+// GetRulesInfo returns the descriptions of the rules of config implementing lint.RuleMetadata,
+// by their name in config.Rules and by their actual name, e.g. for lint.Config.RulesInfo.
+func GetRulesInfo(config *lint.Config, extraRules []lint.Rule) map[string]lint.RuleInfo {
+	rulesMap := availableRules(extraRules)
+	result := map[string]lint.RuleInfo{}
+	for name := range config.Rules {
+		r, ok := rulesMap[actualRuleName(name)]
+		if !ok {
+			continue
+		}
+		if metadata, ok := r.(lint.RuleMetadata); ok {
+			result[name] = metadata.Metadata()
+			result[r.Name()] = result[name]
+		}
+	}
+	return result
+}
+
+// availableRules returns the revive rules and the extra rules, by name.
+// Extra rules named as revive rules are ignored.
+func availableRules(extraRules []lint.Rule) map[string]lint.Rule {
+	rulesMap := map[string]lint.Rule{}
+	for _, r := range allRules {
+		rulesMap[r.Name()] = r
+	}
+	for _, r := range extraRules {
+		if _, ok := rulesMap[r.Name()]; ok {
+			continue
+		}
+		rulesMap[r.Name()] = r
+	}
+	return rulesMap
+}
+
+// cloneRule returns a shallow copy of the given rule, if it is a pointer to a struct,
+// so that configuring the copy does not affect the other configurations of the rule.
+func cloneRule(r lint.Rule) lint.Rule {
+	v := reflect.ValueOf(r)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return r
+	}
+	clone := reflect.New(v.Elem().Type())
+	clone.Elem().Set(v.Elem())
+	return clone.Interface().(lint.Rule)
+}
+
 func actualRuleName(name string) string {
 	switch name {
 	case "imports-blacklist":
@@ -164,16 +217,36 @@ func parseConfig(path string, config *lint.Config) error {
 	if err != nil {
 		return errors.New("cannot read the config file")
 	}
-	_, err = toml.Decode(string(file), config)
+	source, err := toTOML(path, file)
 	if err != nil {
 		return fmt.Errorf("cannot parse the config file: %v", err)
 	}
-	for k, r := range config.Rules {
-		err := r.Initialize()
-		if err != nil {
-			return fmt.Errorf("error in config of rule [%s] : [%v]", k, err)
+	_, err = toml.Decode(source, config)
+	if err != nil {
+		return fmt.Errorf("cannot parse the config file: %v", err)
+	}
+	if len(config.Extends) > 0 {
+		if err := resolveExtends(path, config); err != nil {
+			return err
 		}
-		config.Rules[k] = r
+	} else {
+		for k, r := range config.Rules {
+			err := r.Initialize()
+			if err != nil {
+				return fmt.Errorf("error in config of rule [%s] : [%v]", k, err)
+			}
+			config.Rules[k] = r
+		}
+		for i := range config.ExcludeIssues {
+			if err := config.ExcludeIssues[i].Initialize(); err != nil {
+				return fmt.Errorf("error in config of exclude-issue #%d : [%v]", i+1, err)
+			}
+		}
+	}
+	switch config.TypeCheck.Importer {
+	case "", lint.ImporterDefault, lint.ImporterModule:
+	default:
+		return fmt.Errorf("unknown importer %q in typecheck config, expected %q or %q", config.TypeCheck.Importer, lint.ImporterDefault, lint.ImporterModule)
 	}
 
 	return nil
@@ -28,6 +28,10 @@ func TestGetConfig(t *testing.T) {
 			confPath:  "testdata/malformed.toml",
 			wantError: "cannot parse the config file",
 		},
+		"invalid exclude-issue": {
+			confPath:  "testdata/badExcludeIssue.toml",
+			wantError: "error in config of exclude-issue #2",
+		},
 		"default config": {
 			wantConfig: func() *lint.Config {
 				c := defaultConfig()
@@ -88,6 +92,7 @@ func TestGetLintingRules(t *testing.T) {
 	tt := map[string]struct {
 		confPath       string
 		wantRulesCount int
+		wantError      string
 	}{
 		"no rules": {
 			confPath:       "testdata/noRules.toml",
@@ -105,6 +110,10 @@ func TestGetLintingRules(t *testing.T) {
 			confPath:       "testdata/enable2.toml",
 			wantRulesCount: 2,
 		},
+		"rule with invalid arguments": {
+			confPath:  "testdata/badRuleArguments.toml",
+			wantError: `cannot configure rule: "cyclomatic": invalid argument for cyclomatic complexity; expected int but got string`,
+		},
 	}
 
 	for name, tc := range tt {
@@ -115,8 +124,12 @@ func TestGetLintingRules(t *testing.T) {
 			}
 			rules, err := GetLintingRules(cfg, []lint.Rule{})
 			switch {
-			case err != nil:
+			case err != nil && tc.wantError == "":
 				t.Fatalf("Unexpected error\n\t%v", err)
+			case err != nil && err.Error() != tc.wantError:
+				t.Fatalf("Expected error\n\t%q\ngot:\n\t%v", tc.wantError, err)
+			case err == nil && tc.wantError != "":
+				t.Fatalf("Expected error\n\t%q", tc.wantError)
 			case len(rules) != tc.wantRulesCount:
 				t.Fatalf("Expected %v enabled linting rules got: %v", tc.wantRulesCount, len(rules))
 			}
@@ -124,6 +137,28 @@ func TestGetLintingRules(t *testing.T) {
 	}
 }
 
+func TestGetRulesInfo(t *testing.T) {
+	cfg := &lint.Config{Rules: lint.RulesConfig{
+		"imports-blacklist":   {},
+		"increment-decrement": {},
+		"undocumented":        {},
+	}}
+	info := GetRulesInfo(cfg, []lint.Rule{undocumentedRule{}})
+
+	// the alias is described as the rule it names, under both names
+	for _, name := range []string{"imports-blacklist", "imports-blocklist", "increment-decrement"} {
+		if info[name].Description == "" {
+			t.Errorf("no description of the rule %s in %v", name, info)
+		}
+	}
+	if _, ok := info["undocumented"]; ok {
+		t.Errorf("got a description of the undocumented rule: %+v", info["undocumented"])
+	}
+	if cfg.RulesInfo != nil {
+		t.Errorf("the configuration was modified: %+v", cfg.RulesInfo)
+	}
+}
+
 func TestGetGlobalSeverity(t *testing.T) {
 	tt := map[string]struct {
 		confPath               string
@@ -173,3 +208,22 @@ func TestGetGlobalSeverity(t *testing.T) {
 		})
 	}
 }
+
+func TestGetConfigExcludeIssues(t *testing.T) {
+	cfg, err := GetConfig("testdata/exclude-issue.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		`rule "exported", message "should have comment", path "mocks/**"`,
+		`source "//\\s*legacy$"`,
+	}
+	var got []string
+	for i := range cfg.ExcludeIssues {
+		got = append(got, cfg.ExcludeIssues[i].String())
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got exclusions %q, want %q", got, want)
+	}
+}
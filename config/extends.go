@@ -0,0 +1,170 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mgechev/revive/lint"
+)
+
+// presetPrefix prefixes the names of the built-in configurations in extends.
+const presetPrefix = "preset:"
+
+// golintRules are the names of the rules ported from golint.
+var golintRules = map[string]bool{
+	"var-declaration":     true,
+	"package-comments":    true,
+	"dot-imports":         true,
+	"blank-imports":       true,
+	"exported":            true,
+	"var-naming":          true,
+	"indent-error-flow":   true,
+	"range":               true,
+	"errorf":              true,
+	"error-naming":        true,
+	"error-strings":       true,
+	"receiver-naming":     true,
+	"increment-decrement": true,
+	"error-return":        true,
+	"unexported-return":   true,
+	"time-naming":         true,
+	"context-keys-type":   true,
+	"context-as-argument": true,
+}
+
+// presets returns the rules of each built-in configuration.
+var presets = map[string]func() []lint.Rule{
+	// the rules of golint
+	"golint-compatible": func() []lint.Rule {
+		var result []lint.Rule
+		for _, r := range defaultRules {
+			if golintRules[r.Name()] {
+				result = append(result, r)
+			}
+		}
+		return result
+	},
+	// the rules enabled when no configuration is given
+	"recommended": func() []lint.Rule { return defaultRules },
+	// all the rules, with their default arguments
+	"strict": func() []lint.Rule { return allRules },
+}
+
+// presetLayer returns the built-in configuration of the given name.
+// It defines the severity, the confidence and the enabled rules.
+func presetLayer(name string) (*configLayer, error) {
+	rules, ok := presets[name]
+	if !ok {
+		names := make([]string, 0, len(presets))
+		for n := range presets {
+			names = append(names, presetPrefix+n)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("unknown preset %q, expected one of %s", presetPrefix+name, strings.Join(names, ", "))
+	}
+
+	layer := &configLayer{
+		path: presetPrefix + name,
+		config: lint.Config{
+			Confidence: defaultConfidence,
+			Severity:   lint.SeverityWarning,
+			Rules:      lint.RulesConfig{},
+		},
+		defined: map[string]bool{"confidence": true, "severity": true},
+	}
+	for _, r := range rules() {
+		layer.config.Rules[r.Name()] = lint.RuleConfig{}
+	}
+	return layer, nil
+}
+
+// loadConfigLayer reads the configuration file at path, merged on top of the configurations it extends.
+// extendedBy holds the absolute paths of the files extending, directly or not, the file.
+func loadConfigLayer(path string, extendedBy []string) (*configLayer, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	for i, p := range extendedBy {
+		if p == abs {
+			return nil, fmt.Errorf("cyclic extends: %s", strings.Join(append(extendedBy[i:], abs), " -> "))
+		}
+	}
+
+	layer, err := readConfigLayer(path)
+	if err != nil {
+		if len(extendedBy) > 0 {
+			return nil, fmt.Errorf("%w (extended by %s)", err, extendedBy[len(extendedBy)-1])
+		}
+		return nil, err
+	}
+	if len(layer.config.Extends) == 0 {
+		return layer, nil
+	}
+
+	extendedBy = append(extendedBy[:len(extendedBy):len(extendedBy)], abs)
+	result := &configLayer{
+		path:    path,
+		root:    layer.root,
+		config:  lint.Config{Rules: lint.RulesConfig{}, Directives: lint.DirectivesConfig{}},
+		defined: map[string]bool{},
+	}
+	for _, extended := range layer.config.Extends {
+		var base *configLayer
+		if name, ok := strings.CutPrefix(extended, presetPrefix); ok {
+			base, err = presetLayer(name)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", path, err)
+			}
+		} else {
+			if !filepath.IsAbs(extended) {
+				extended = filepath.Join(filepath.Dir(path), extended)
+			}
+			base, err = loadConfigLayer(extended, extendedBy)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if err := result.merge(base); err != nil {
+			return nil, err
+		}
+	}
+	if err := result.merge(layer); err != nil {
+		return nil, err
+	}
+	result.config.Extends = layer.config.Extends
+	return result, nil
+}
+
+// merge overrides the settings of the layer with those defined in other.
+func (l *configLayer) merge(other *configLayer) error {
+	if err := other.mergeInto(&l.config); err != nil {
+		return fmt.Errorf("%s: %w", other.path, err)
+	}
+	for key := range other.defined {
+		l.defined[key] = true
+	}
+	return nil
+}
+
+// resolveExtends replaces config, read from path, by its merge on top of the configurations it extends.
+func resolveExtends(path string, config *lint.Config) error {
+	layer, err := loadConfigLayer(path, nil)
+	if err != nil {
+		return err
+	}
+
+	merged := &lint.Config{
+		Confidence: defaultConfidence,
+		Rules:      lint.RulesConfig{},
+		Directives: lint.DirectivesConfig{},
+	}
+	if err := layer.mergeInto(merged); err != nil {
+		return err
+	}
+	merged.Extends = layer.config.Extends
+	*config = *merged
+	return nil
+}
@@ -0,0 +1,191 @@
+package config
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mgechev/revive/lint"
+)
+
+func TestGetConfigExtends(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"shared/revive.toml": `
+confidence = 0.3
+exclude = ["vendor/..."]
+[[exclude-issue]]
+rule = "exported"
+[rule.line-length-limit]
+arguments = [80]
+severity = "error"
+exclude = ["gen/**"]
+[rule.cyclomatic]
+arguments = [10]
+[directive.specify-disable-reason]
+`,
+		"svc/revive.toml": `
+extends = ["preset:golint-compatible", "../shared/revive.toml"]
+exclude = ["testdata/..."]
+[[exclude-issue]]
+path = "mocks/**"
+[rule.line-length-limit]
+arguments = [120]
+[rule.cyclomatic]
+disabled = true
+[rule.var-naming]
+severity = "error"
+`,
+	})
+
+	config, err := GetConfig(filepath.Join(dir, "svc/revive.toml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if config.Confidence != 0.3 {
+		t.Errorf("got confidence %v, want the extended 0.3", config.Confidence)
+	}
+	if want := []string{"vendor/...", "testdata/..."}; !reflect.DeepEqual(config.Exclude, want) {
+		t.Errorf("got exclude %v, want %v", config.Exclude, want)
+	}
+	if got := len(config.ExcludeIssues); got != 2 || config.ExcludeIssues[0].Rule != "exported" || config.ExcludeIssues[1].Path != "mocks/**" {
+		t.Errorf("expected the exclude-issue entries to be concatenated, got %+v", config.ExcludeIssues)
+	}
+	if _, ok := config.Directives["specify-disable-reason"]; !ok {
+		t.Errorf("expected the extended directive, got %+v", config.Directives)
+	}
+
+	// arguments are overridden, the severity and the exclude are inherited
+	llc := config.Rules["line-length-limit"]
+	if llc.Severity != lint.SeverityError || !reflect.DeepEqual(llc.Arguments, lint.Arguments{int64(120)}) {
+		t.Errorf("unexpected line-length-limit config %+v", llc)
+	}
+	if !llc.MustExclude("gen/x.go") {
+		t.Errorf("expected the inherited exclude of line-length-limit to be initialized")
+	}
+	if got := config.Rules["var-naming"].Severity; got != lint.SeverityError {
+		t.Errorf("got var-naming severity %q, want %q", got, lint.SeverityError)
+	}
+	// the top level severity of the preset applies to the other rules
+	if got := config.Rules["exported"].Severity; got != lint.SeverityWarning {
+		t.Errorf("got exported severity %q, want %q", got, lint.SeverityWarning)
+	}
+
+	rules, err := GetLintingRules(config, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := ruleNames(rules)
+	for _, name := range names {
+		if !golintRules[name] && name != "line-length-limit" {
+			t.Errorf("unexpected rule %s", name)
+		}
+	}
+	if len(names) != len(golintRules)+1 {
+		t.Errorf("got rules %v, want the golint rules and line-length-limit", names)
+	}
+}
+
+func TestGetConfigExtendsPresets(t *testing.T) {
+	tests := []struct {
+		preset string
+		want   []lint.Rule
+	}{
+		{"recommended", defaultRules},
+		{"strict", allRules},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.preset, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, map[string]string{"revive.toml": `extends = ["preset:` + tt.preset + `"]`})
+
+			config, err := GetConfig(filepath.Join(dir, "revive.toml"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			rules, err := GetLintingRules(config, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := ruleNames(rules), ruleNames(tt.want); !reflect.DeepEqual(got, want) {
+				t.Errorf("got rules %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestGetConfigExtendsErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		wantErr string
+	}{
+		{
+			name: "cycle",
+			files: map[string]string{
+				"revive.toml": `extends = ["a/a.toml"]`,
+				"a/a.toml":    `extends = ["../b/b.toml"]`,
+				"b/b.toml":    `extends = ["../a/a.toml"]`,
+			},
+			wantErr: "cyclic extends: ",
+		},
+		{
+			name:    "self",
+			files:   map[string]string{"revive.toml": `extends = ["revive.toml"]`},
+			wantErr: "cyclic extends: ",
+		},
+		{
+			name:    "missing file",
+			files:   map[string]string{"revive.toml": `extends = ["missing.toml"]`},
+			wantErr: "missing.toml",
+		},
+		{
+			name:    "unknown preset",
+			files:   map[string]string{"revive.toml": `extends = ["preset:lenient"]`},
+			wantErr: `unknown preset "preset:lenient", expected one of preset:golint-compatible, preset:recommended, preset:strict`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, tt.files)
+
+			_, err := GetConfig(filepath.Join(dir, "revive.toml"))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("got error %v, want one containing %q", err, tt.wantErr)
+			}
+			if tt.name == "missing file" && !strings.Contains(err.Error(), "extended by") {
+				t.Errorf("expected the extending file in the error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestHierarchyResolveExtends(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		".git/HEAD":       "",
+		"shared.toml":     "[rule.cyclomatic]\narguments = [5]\n",
+		"pkg/revive.toml": "extends = [\"../shared.toml\"]\n",
+		"pkg/sub/x.go":    "package sub",
+	})
+
+	rules, config, err := NewHierarchy(defaultConfig(), "", nil, nil).Resolve(filepath.Join(dir, "pkg/sub"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := config.Rules["cyclomatic"].Arguments; !reflect.DeepEqual(got, lint.Arguments{int64(5)}) {
+		t.Errorf("got cyclomatic arguments %v, want [5]", got)
+	}
+	found := false
+	for _, r := range rules {
+		found = found || r.Name() == "cyclomatic"
+	}
+	if !found {
+		t.Errorf("expected the extended rule cyclomatic, got %v", ruleNames(rules))
+	}
+}
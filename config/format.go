@@ -0,0 +1,167 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// fileNames are the names of the configuration files looked up in a directory, by order of precedence.
+var fileNames = []string{"revive.toml", ".revive.yaml", ".revive.yml", ".revive.json"}
+
+// FileNames returns the names of the configuration files looked up in a directory,
+// by order of precedence: revive.toml, .revive.yaml, .revive.yml and .revive.json.
+func FileNames() []string {
+	return append([]string{}, fileNames...)
+}
+
+// FindConfigFile returns the path of the configuration file in dir, or an empty string if there is none.
+// See FileNames for the files looked up.
+func FindConfigFile(dir string) string {
+	return FindConfigFileIn(osFS{}, dir)
+}
+
+// StatFS is a file system the configuration files are looked up in, e.g. an afero.Fs.
+type StatFS interface {
+	Stat(name string) (os.FileInfo, error)
+}
+
+type osFS struct{}
+
+func (osFS) Stat(name string) (os.FileInfo, error) { return os.Stat(name) }
+
+// FindConfigFileIn is FindConfigFile looking up the files in fsys.
+func FindConfigFileIn(fsys StatFS, dir string) string {
+	for _, name := range fileNames {
+		path := filepath.Join(dir, name)
+		if info, err := fsys.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+	}
+	return ""
+}
+
+// toTOML returns the TOML source of the content of the configuration file at path.
+// YAML (.yaml, .yml) and JSON (.json) files are converted to TOML, so that
+// they share the schema of the TOML files and the rules get their arguments
+// with the same types, e.g. int64 for integers.
+func toTOML(path string, data []byte) (string, error) {
+	var decode func([]byte) (map[string]any, error)
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		decode = decodeYAML
+	case ".json":
+		decode = decodeJSON
+	default:
+		return string(data), nil
+	}
+
+	values, err := decode(data)
+	if err != nil {
+		return "", err
+	}
+	normalized, err := toTOMLValue(values, "")
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(normalized); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func decodeYAML(data []byte) (map[string]any, error) {
+	values := map[string]any{}
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+func decodeJSON(data []byte) (map[string]any, error) {
+	values := map[string]any{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&values); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("unexpected data after the top-level object")
+	}
+	return values, nil
+}
+
+// toTOMLValue converts the decoded value v, at the given key, to the types decoded from TOML.
+func toTOMLValue(v any, key string) (any, error) {
+	switch v := v.(type) {
+	case int:
+		return int64(v), nil
+	case uint64:
+		return int64(v), nil
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i, nil
+		}
+		return v.Float64()
+	case map[string]any:
+		result := make(map[string]any, len(v))
+		for k, e := range v {
+			if e == nil {
+				// e.g. a rule enabled with "exported:" in YAML
+				result[k] = map[string]any{}
+				continue
+			}
+			value, err := toTOMLValue(e, joinKey(key, k))
+			if err != nil {
+				return nil, err
+			}
+			result[k] = value
+		}
+		return result, nil
+	case map[any]any:
+		result := make(map[string]any, len(v))
+		for k, e := range v {
+			name := fmt.Sprint(k)
+			if e == nil {
+				result[name] = map[string]any{}
+				continue
+			}
+			value, err := toTOMLValue(e, joinKey(key, name))
+			if err != nil {
+				return nil, err
+			}
+			result[name] = value
+		}
+		return result, nil
+	case []any:
+		result := make([]any, len(v))
+		for i, e := range v {
+			if e == nil {
+				return nil, fmt.Errorf("null value in %s", key)
+			}
+			value, err := toTOMLValue(e, fmt.Sprintf("%s[%d]", key, i))
+			if err != nil {
+				return nil, err
+			}
+			result[i] = value
+		}
+		return result, nil
+	default:
+		return v, nil
+	}
+}
+
+func joinKey(key, name string) string {
+	if key == "" {
+		return name
+	}
+	return key + "." + name
+}
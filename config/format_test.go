@@ -0,0 +1,132 @@
+package config
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mgechev/revive/lint"
+)
+
+func TestGetConfigFormats(t *testing.T) {
+	files := map[string]string{
+		"revive.toml": `
+confidence = 0.6
+severity = "warning"
+errorCode = 1
+exclude = ["vendor/..."]
+[rule.exported]
+[rule.line-length-limit]
+arguments = [120]
+severity = "error"
+[rule.add-constant]
+arguments = [{maxLitCount = "3", allowInts = "0,1"}]
+[rule.var-naming]
+arguments = [["ID"], ["VM"], [{upperCaseConst = true}]]
+exclude = ["gen/**"]
+[rule.cognitive-complexity]
+disabled = true
+[directive.specify-disable-reason]
+`,
+		".revive.yaml": `
+confidence: 0.6
+severity: warning
+errorCode: 1
+exclude: [vendor/...]
+rule:
+  exported:
+  line-length-limit:
+    arguments: [120]
+    severity: error
+  add-constant:
+    arguments:
+      - maxLitCount: "3"
+        allowInts: "0,1"
+  var-naming:
+    arguments: [[ID], [VM], [{upperCaseConst: true}]]
+    exclude: [gen/**]
+  cognitive-complexity:
+    disabled: true
+directive:
+  specify-disable-reason: {}
+`,
+		".revive.json": `{
+  "confidence": 0.6,
+  "severity": "warning",
+  "errorCode": 1,
+  "exclude": ["vendor/..."],
+  "rule": {
+    "exported": {},
+    "line-length-limit": {"arguments": [120], "severity": "error"},
+    "add-constant": {"arguments": [{"maxLitCount": "3", "allowInts": "0,1"}]},
+    "var-naming": {"arguments": [["ID"], ["VM"], [{"upperCaseConst": true}]], "exclude": ["gen/**"]},
+    "cognitive-complexity": {"disabled": true}
+  },
+  "directive": {"specify-disable-reason": {}}
+}`,
+	}
+	dir := t.TempDir()
+	writeFiles(t, dir, files)
+
+	want, err := GetConfig(filepath.Join(dir, "revive.toml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := want.Rules["line-length-limit"].Arguments; !reflect.DeepEqual(got, lint.Arguments{int64(120)}) {
+		t.Fatalf("unexpected TOML arguments %#v", got)
+	}
+
+	for _, name := range []string{".revive.yaml", ".revive.json"} {
+		t.Run(name, func(t *testing.T) {
+			got, err := GetConfig(filepath.Join(dir, name))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("got config\n%#v\nwant the TOML one\n%#v", got, want)
+			}
+			if vn := got.Rules["var-naming"]; !vn.MustExclude("gen/x.go") {
+				t.Errorf("expected the exclude of var-naming to be initialized")
+			}
+			if _, err := GetLintingRules(got, nil); err != nil {
+				t.Errorf("cannot configure the rules: %v", err)
+			}
+		})
+	}
+}
+
+func TestGetConfigFormatErrors(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"bad.yaml":  "rule: [",
+		"bad.json":  `{"rule": {}} {}`,
+		"null.yaml": "exclude: [~]",
+	})
+
+	for _, name := range []string{"bad.yaml", "bad.json", "null.yaml"} {
+		t.Run(name, func(t *testing.T) {
+			_, err := GetConfig(filepath.Join(dir, name))
+			if err == nil || !strings.Contains(err.Error(), "cannot parse the config file") {
+				t.Errorf("got error %v, want a parse error", err)
+			}
+		})
+	}
+}
+
+func TestFindConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	if got := FindConfigFile(dir); got != "" {
+		t.Errorf("got %q in an empty directory", got)
+	}
+
+	writeFiles(t, dir, map[string]string{".revive.json": "{}", ".revive.yml": ""})
+	if got, want := FindConfigFile(dir), filepath.Join(dir, ".revive.yml"); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	writeFiles(t, dir, map[string]string{"revive.toml": ""})
+	if got, want := FindConfigFile(dir), filepath.Join(dir, "revive.toml"); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,336 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/mgechev/revive/lint"
+)
+
+// Hierarchy resolves the configuration of a package by merging the configuration
+// files (see FindConfigFile) of its directory and of its ancestors, up to the root
+// of the repository (i.e. the directory containing .git), on top of a base configuration.
+//
+// Files are merged from the farthest to the nearest, the nearest one winning
+// for each setting of a rule (arguments, severity, disabled, exclude and override),
+// each directive, and each top level setting but exclude: files are selected with
+// the exclude patterns of the base configuration only. A top level severity applies
+// to the rules and directives inherited from the farther files too.
+// A file with "root = true" stops the inheritance: it is loaded as a standalone
+// configuration, ignoring the files of its ancestors and the base configuration.
+type Hierarchy struct {
+	base       *lint.Config
+	basePath   string
+	baseRules  []lint.Rule
+	extraRules []lint.Rule
+
+	mu       sync.Mutex
+	resolved map[string]*resolvedConfig
+	// files and roots cache the configuration files by path, so that the
+	// issue exclusions of a file count the failures of all its packages
+	files map[string]*configLayer
+	roots map[string]*lint.Config
+}
+
+type resolvedConfig struct {
+	rules  []lint.Rule
+	config lint.Config
+	err    error
+}
+
+// NewHierarchy returns the hierarchy of the configurations on top of base,
+// whose rules are baseRules and which is read from basePath, if not empty.
+// extraRules are the rules that can be configured besides the revive ones.
+func NewHierarchy(base *lint.Config, basePath string, baseRules, extraRules []lint.Rule) *Hierarchy {
+	if basePath != "" {
+		if abs, err := filepath.Abs(basePath); err == nil {
+			basePath = abs
+		}
+	}
+	return &Hierarchy{
+		base:       base,
+		basePath:   basePath,
+		baseRules:  baseRules,
+		extraRules: extraRules,
+		resolved:   map[string]*resolvedConfig{},
+		files:      map[string]*configLayer{},
+		roots:      map[string]*lint.Config{},
+	}
+}
+
+// Resolve returns the rules and the configuration of the package in dir.
+// It implements lint.ConfigResolver.
+func (h *Hierarchy) Resolve(dir string) ([]lint.Rule, lint.Config, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, lint.Config{}, err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	r, ok := h.resolved[dir]
+	if !ok {
+		r = h.resolve(dir)
+		h.resolved[dir] = r
+	}
+	return r.rules, r.config, r.err
+}
+
+func (h *Hierarchy) resolve(dir string) *resolvedConfig {
+	layers, err := h.layers(dir)
+	if err != nil {
+		return &resolvedConfig{err: err}
+	}
+	if len(layers) == 0 {
+		return &resolvedConfig{rules: h.baseRules, config: *h.base}
+	}
+
+	var config *lint.Config
+	if root := layers[0]; root.root {
+		rootConfig, ok := h.roots[root.path]
+		if !ok {
+			rootConfig, err = GetConfig(root.path)
+			if err != nil {
+				return &resolvedConfig{err: fmt.Errorf("%s: %w", root.path, err)}
+			}
+			h.roots[root.path] = rootConfig
+		}
+		config = copyConfig(rootConfig)
+		layers = layers[1:]
+	} else {
+		config = copyConfig(h.base)
+	}
+
+	for _, layer := range layers {
+		if err := layer.mergeInto(config); err != nil {
+			return &resolvedConfig{err: fmt.Errorf("%s: %w", layer.path, err)}
+		}
+	}
+	normalizeConfig(config)
+	// files are selected before the configuration of their package is resolved
+	config.Exclude = h.base.Exclude
+
+	rules, err := GetLintingRules(config, h.extraRules)
+	if err != nil {
+		return &resolvedConfig{err: fmt.Errorf("configuration of %s: %w", dir, err)}
+	}
+	return &resolvedConfig{rules: rules, config: *config}
+}
+
+// ExcludeIssues returns the issue exclusions of the configuration files
+// used so far, besides the base configuration, indexed by path.
+func (h *Hierarchy) ExcludeIssues() map[string][]lint.IssueExclusion {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	result := map[string][]lint.IssueExclusion{}
+	for path, layer := range h.files {
+		if len(layer.config.ExcludeIssues) > 0 {
+			result[path] = layer.config.ExcludeIssues
+		}
+	}
+	for path, config := range h.roots {
+		if len(config.ExcludeIssues) > 0 {
+			result[path] = config.ExcludeIssues
+		}
+	}
+	return result
+}
+
+// layers returns the configuration files applying to dir, from the farthest to the nearest.
+func (h *Hierarchy) layers(dir string) ([]*configLayer, error) {
+	var layers []*configLayer
+	for {
+		if path := FindConfigFile(dir); path != "" && path != h.basePath {
+			layer, ok := h.files[path]
+			if !ok {
+				var err error
+				if layer, err = loadConfigLayer(path, nil); err != nil {
+					return nil, err
+				}
+				h.files[path] = layer
+			}
+			layers = append([]*configLayer{layer}, layers...)
+			if layer.root {
+				break
+			}
+		}
+
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			break // root of the repository
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+	return layers, nil
+}
+
+// configLayer is a configuration file of the hierarchy.
+type configLayer struct {
+	path   string
+	root   bool
+	config lint.Config
+	// defined holds the lowercase keys defined in the file, e.g. "rule.var-naming.arguments"
+	defined map[string]bool
+}
+
+func readConfigLayer(path string) (*configLayer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the config file %s: %w", path, err)
+	}
+
+	source, err := toTOML(path, data)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse the config file %s: %v", path, err)
+	}
+
+	var layer struct {
+		Root bool `toml:"root"`
+	}
+	if _, err := toml.Decode(source, &layer); err != nil {
+		return nil, fmt.Errorf("cannot parse the config file %s: %v", path, err)
+	}
+
+	result := &configLayer{path: path, root: layer.Root, defined: map[string]bool{}}
+	meta, err := toml.Decode(source, &result.config)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse the config file %s: %v", path, err)
+	}
+	for _, key := range meta.Keys() {
+		result.defined[strings.ToLower(key.String())] = true
+	}
+	for i := range result.config.ExcludeIssues {
+		if err := result.config.ExcludeIssues[i].Initialize(); err != nil {
+			return nil, fmt.Errorf("error in config of exclude-issue #%d of %s: [%v]", i+1, path, err)
+		}
+	}
+	return result, nil
+}
+
+func (l *configLayer) isDefined(keys ...string) bool {
+	return l.defined[strings.ToLower(strings.Join(keys, "."))]
+}
+
+// mergeInto overrides the settings of config with those defined in the layer.
+func (l *configLayer) mergeInto(config *lint.Config) error {
+	if l.isDefined("confidence") {
+		config.Confidence = l.config.Confidence
+	}
+	if l.isDefined("severity") {
+		config.Severity = l.config.Severity
+		for name, rc := range config.Rules {
+			rc.Severity = l.config.Severity
+			config.Rules[name] = rc
+		}
+		for name, dc := range config.Directives {
+			dc.Severity = l.config.Severity
+			config.Directives[name] = dc
+		}
+	}
+	if l.isDefined("ignoreGeneratedHeader") {
+		config.IgnoreGeneratedHeader = l.config.IgnoreGeneratedHeader
+	}
+	if l.isDefined("enableNolint") {
+		config.EnableNolint = l.config.EnableNolint
+	}
+	if l.isDefined("enableAllRules") {
+		config.EnableAllRules = l.config.EnableAllRules
+	}
+	if l.isDefined("errorCode") {
+		config.ErrorCode = l.config.ErrorCode
+	}
+	if l.isDefined("warningCode") {
+		config.WarningCode = l.config.WarningCode
+	}
+	if l.isDefined("exclude") {
+		config.Exclude = append(append([]string{}, config.Exclude...), l.config.Exclude...)
+	}
+	if l.isDefined("exclude-issue") {
+		// the exclusions are initialized by readConfigLayer, their copies share their counters
+		config.ExcludeIssues = append(append([]lint.IssueExclusion{}, config.ExcludeIssues...), l.config.ExcludeIssues...)
+	}
+	if l.isDefined("build", "tags") {
+		config.Build.Tags = l.config.Build.Tags
+	}
+	if l.isDefined("build", "goos") {
+		config.Build.GOOS = l.config.Build.GOOS
+	}
+	if l.isDefined("build", "goarch") {
+		config.Build.GOARCH = l.config.Build.GOARCH
+	}
+	if l.isDefined("build", "matrix") {
+		config.Build.Matrix = l.config.Build.Matrix
+	}
+	if l.isDefined("typecheck", "importer") {
+		config.TypeCheck.Importer = l.config.TypeCheck.Importer
+	}
+	if l.isDefined("typecheck", "reportErrors") {
+		config.TypeCheck.ReportErrors = l.config.TypeCheck.ReportErrors
+	}
+
+	if config.Rules == nil {
+		config.Rules = lint.RulesConfig{}
+	}
+	if config.Directives == nil && len(l.config.Directives) > 0 {
+		config.Directives = lint.DirectivesConfig{}
+	}
+	for name, dc := range l.config.Directives {
+		config.Directives[name] = dc
+	}
+
+	for name, rc := range l.config.Rules {
+		merged := config.Rules[name]
+		if l.isDefined("rule", name, "arguments") {
+			merged.Arguments = rc.Arguments
+		}
+		if l.isDefined("rule", name, "severity") {
+			merged.Severity = rc.Severity
+		}
+		if l.isDefined("rule", name, "disabled") {
+			merged.Disabled = rc.Disabled
+		}
+		if l.isDefined("rule", name, "exclude") {
+			merged.Exclude = rc.Exclude
+		}
+		if l.isDefined("rule", name, "override") {
+			merged.Override = rc.Override
+		}
+
+		// reset the exclude filters of the merged configuration
+		merged = lint.RuleConfig{
+			Arguments: merged.Arguments,
+			Severity:  merged.Severity,
+			Disabled:  merged.Disabled,
+			Exclude:   merged.Exclude,
+			Override:  merged.Override,
+		}
+		if err := merged.Initialize(); err != nil {
+			return fmt.Errorf("error in config of rule [%s] : [%v]", name, err)
+		}
+		config.Rules[name] = merged
+	}
+
+	return nil
+}
+
+// copyConfig returns a copy of config whose rules and directives can be modified.
+func copyConfig(config *lint.Config) *lint.Config {
+	result := *config
+	result.Rules = make(lint.RulesConfig, len(config.Rules))
+	for name, rc := range config.Rules {
+		result.Rules[name] = rc
+	}
+	result.Directives = make(lint.DirectivesConfig, len(config.Directives))
+	for name, dc := range config.Directives {
+		result.Directives[name] = dc
+	}
+	return &result
+}
@@ -0,0 +1,180 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/mgechev/revive/lint"
+)
+
+// writeFiles creates the given files, and their directories, in dir.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func ruleNames(rules []lint.Rule) []string {
+	names := make([]string, len(rules))
+	for i, r := range rules {
+		names[i] = r.Name()
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestHierarchyResolve(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"repo/.git/HEAD": "",
+		"repo/revive.toml": `
+confidence = 0.5
+[rule.exported]
+[rule.line-length-limit]
+arguments = [80]
+severity = "error"
+`,
+		"repo/legacy/revive.toml": `
+[rule.exported]
+disabled = true
+[rule.line-length-limit]
+arguments = [120]
+`,
+		"repo/legacy/gen/revive.toml": `
+root = true
+[rule.var-naming]
+`,
+		"repo/legacy/gen/pb/x.go": "package pb",
+		"repo/new/x.go":           "package x",
+		"repo/strict/revive.toml": "severity = \"error\"\n",
+		"repo/strict/x.go":        "package x",
+	})
+	// the file above the repository root must be ignored
+	writeFiles(t, dir, map[string]string{"revive.toml": "[rule.cyclomatic]\narguments = [\"ten\"]\n"})
+
+	base, err := GetConfig("testdata/enable2.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	baseRules, err := GetLintingRules(base, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := NewHierarchy(base, "testdata/enable2.toml", baseRules, nil)
+
+	tests := []struct {
+		dir            string
+		wantRules      []string
+		wantConfidence float64
+		check          func(t *testing.T, config lint.Config)
+	}{
+		{
+			dir:            "repo/new",
+			wantRules:      []string{"cyclomatic", "exported", "line-length-limit"},
+			wantConfidence: 0.5,
+			check: func(t *testing.T, config lint.Config) {
+				if got := config.Rules["line-length-limit"]; got.Severity != lint.SeverityError || !reflect.DeepEqual(got.Arguments, lint.Arguments{int64(80)}) {
+					t.Errorf("unexpected line-length-limit config %+v", got)
+				}
+			},
+		},
+		{
+			dir:            "repo/legacy",
+			wantRules:      []string{"cyclomatic", "line-length-limit"},
+			wantConfidence: 0.5,
+			check: func(t *testing.T, config lint.Config) {
+				// arguments are overridden, severity is inherited
+				if got := config.Rules["line-length-limit"]; got.Severity != lint.SeverityError || !reflect.DeepEqual(got.Arguments, lint.Arguments{int64(120)}) {
+					t.Errorf("unexpected line-length-limit config %+v", got)
+				}
+			},
+		},
+		{
+			dir:            "repo/strict",
+			wantRules:      []string{"cyclomatic", "exported", "line-length-limit"},
+			wantConfidence: 0.5,
+			check: func(t *testing.T, config lint.Config) {
+				// the top level severity applies to the inherited rules
+				if got := config.Rules["cyclomatic"]; got.Severity != lint.SeverityError {
+					t.Errorf("unexpected cyclomatic config %+v", got)
+				}
+			},
+		},
+		{
+			dir:            "repo/legacy/gen/pb",
+			wantRules:      []string{"var-naming"},
+			wantConfidence: defaultConfidence,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dir, func(t *testing.T) {
+			rules, config, err := h.Resolve(filepath.Join(dir, tt.dir))
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := append([]string{}, tt.wantRules...)
+			sort.Strings(want)
+			if got := ruleNames(rules); !reflect.DeepEqual(got, want) {
+				t.Errorf("got rules %v, want %v", got, want)
+			}
+			if config.Confidence != tt.wantConfidence {
+				t.Errorf("got confidence %v, want %v", config.Confidence, tt.wantConfidence)
+			}
+			if tt.check != nil {
+				tt.check(t, config)
+			}
+		})
+	}
+
+	// the base configuration is left untouched
+	if _, ok := base.Rules["line-length-limit"]; ok || base.Rules["exported"].Disabled {
+		t.Errorf("base configuration modified: %+v", base.Rules)
+	}
+}
+
+func TestHierarchyResolveWithoutNestedConfig(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{".git/HEAD": "", "pkg/x.go": "package pkg"})
+
+	base, err := GetConfig("testdata/enable2.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	baseRules, err := GetLintingRules(base, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rules, config, err := NewHierarchy(base, "", baseRules, nil).Resolve(filepath.Join(dir, "pkg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(rules, baseRules) || !reflect.DeepEqual(config, *base) {
+		t.Errorf("expected the base rules and configuration, got %v and %+v", ruleNames(rules), config)
+	}
+}
+
+func TestHierarchyResolveError(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		".git/HEAD":       "",
+		"pkg/revive.toml": "[rule.no-such-rule]\n",
+	})
+
+	_, _, err := NewHierarchy(defaultConfig(), "", nil, nil).Resolve(filepath.Join(dir, "pkg"))
+	if err == nil || !strings.Contains(err.Error(), "cannot find rule: no-such-rule") {
+		t.Fatalf("expected an unknown rule error, got %v", err)
+	}
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"sort"
+
+	"github.com/mgechev/revive/lint"
+)
+
+// RuleDescription describes an available rule.
+type RuleDescription struct {
+	Name string
+	lint.RuleInfo
+	// Documented is whether the rule implements lint.RuleMetadata, as all the revive rules do,
+	// otherwise only its name is known.
+	Documented bool
+}
+
+// DescribeRules returns the descriptions of the available rules, the revive ones then extraRules,
+// sorted by name. Extra rules named as revive rules are ignored, as by GetLintingRules.
+func DescribeRules(extraRules []lint.Rule) []RuleDescription {
+	var result []RuleDescription
+	seen := map[string]bool{}
+	for _, r := range append(allRules[:len(allRules):len(allRules)], extraRules...) {
+		if seen[r.Name()] {
+			continue
+		}
+		seen[r.Name()] = true
+		result = append(result, describeRule(r))
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// DescribeRule returns the description of the available rule with the given name, if any.
+func DescribeRule(name string, extraRules []lint.Rule) (RuleDescription, bool) {
+	for _, description := range DescribeRules(extraRules) {
+		if description.Name == actualRuleName(name) {
+			return description, true
+		}
+	}
+	return RuleDescription{}, false
+}
+
+func describeRule(r lint.Rule) RuleDescription {
+	description := RuleDescription{Name: r.Name()}
+	if metadata, ok := r.(lint.RuleMetadata); ok {
+		description.RuleInfo = metadata.Metadata()
+		description.Documented = true
+	}
+	return description
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/mgechev/revive/lint"
+)
+
+type undocumentedRule struct{}
+
+func (undocumentedRule) Name() string { return "undocumented" }
+
+func (undocumentedRule) Apply(*lint.File, lint.Arguments) []lint.Failure { return nil }
+
+func TestDescribeRules(t *testing.T) {
+	defaults := map[string]bool{}
+	for _, r := range defaultRules {
+		defaults[r.Name()] = true
+	}
+
+	rules := DescribeRules([]lint.Rule{undocumentedRule{}})
+	if len(rules) != len(allRules)+1 {
+		t.Fatalf("got %d rules, want %d", len(rules), len(allRules)+1)
+	}
+	for i, r := range rules {
+		if i > 0 && rules[i-1].Name >= r.Name {
+			t.Errorf("rule %s is not sorted after %s", r.Name, rules[i-1].Name)
+		}
+		if r.DefaultEnabled != defaults[r.Name] {
+			t.Errorf("rule %s is default enabled %t, want %t", r.Name, r.DefaultEnabled, defaults[r.Name])
+		}
+		if r.Name == "undocumented" {
+			continue
+		}
+		if !r.Documented || r.Description == "" || r.Rationale == "" || r.Category == "" {
+			t.Errorf("rule %s is not documented: %+v", r.Name, r.RuleInfo)
+		}
+		if defaults[r.Name] && len(r.Examples) == 0 {
+			t.Errorf("default rule %s has no example: %+v", r.Name, r.RuleInfo)
+		}
+	}
+}
+
+func TestDescribeRule(t *testing.T) {
+	if r, ok := DescribeRule("undocumented", []lint.Rule{undocumentedRule{}}); !ok || r.Documented {
+		t.Errorf("got %+v, %t for an undocumented extra rule", r, ok)
+	}
+	if r, ok := DescribeRule("exported", nil); !ok || !r.Documented {
+		t.Errorf("got %+v, %t for the exported rule", r, ok)
+	}
+	if _, ok := DescribeRule("unknown", nil); ok {
+		t.Error("got a description of an unknown rule")
+	}
+}
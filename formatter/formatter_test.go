@@ -163,3 +163,44 @@ test.go
 		})
 	}
 }
+
+func TestFormatterRuleHelp(t *testing.T) {
+	cfg := lint.Config{
+		Rules: lint.RulesConfig{"rule": {}, "other": {}},
+		RulesInfo: map[string]lint.RuleInfo{
+			"rule": {
+				Description: "Reports tests.",
+				Rationale:   "Tests are bad.",
+				Examples:    []lint.RuleExample{{Bad: "test()", Good: "run()"}},
+			},
+		},
+	}
+	format := func(f lint.Formatter) string {
+		failures := make(chan lint.Failure, 1)
+		failures <- lint.Failure{Failure: "test failure", RuleName: "rule"}
+		close(failures)
+		output, err := f.Format(failures, cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return output
+	}
+
+	sarif := format(&formatter.Sarif{})
+	for _, want := range []string{
+		`"id": "other"`,
+		`"shortDescription": {
+                "text": "Reports tests."`,
+		`"fullDescription": {
+                "text": "Reports tests. Tests are bad."`,
+		`"markdown": "Reports tests. Tests are bad.\n\nBad:\n` + "```go\\ntest()\\n```" + `\n\nGood:\n` + "```go\\nrun()\\n```" + `"`,
+	} {
+		if !strings.Contains(sarif, want) {
+			t.Errorf("SARIF output %s does not contain %s", sarif, want)
+		}
+	}
+
+	if friendly := format(&formatter.Friendly{}); !strings.Contains(friendly, "1  rule  Reports tests.") {
+		t.Errorf("friendly output %q does not contain the description of the rule", friendly)
+	}
+}
@@ -50,8 +50,8 @@ func (f *Friendly) Format(failures <-chan lint.Failure, config lint.Config) (str
 		}
 	}
 	f.printSummary(&buf, totalErrors, totalWarnings)
-	f.printStatistics(&buf, color.RedString("Errors:"), errorMap)
-	f.printStatistics(&buf, color.YellowString("Warnings:"), warningMap)
+	f.printStatistics(&buf, color.RedString("Errors:"), errorMap, config.RulesInfo)
+	f.printStatistics(&buf, color.YellowString("Warnings:"), warningMap, config.RulesInfo)
 	return buf.String(), nil
 }
 
@@ -109,7 +109,8 @@ func (*Friendly) printSummary(w io.Writer, errors, warnings int) {
 	}
 }
 
-func (f *Friendly) printStatistics(w io.Writer, header string, stats map[string]int) {
+// printStatistics prints the number of failures by rule, with the descriptions of the rules if known.
+func (f *Friendly) printStatistics(w io.Writer, header string, stats map[string]int, info map[string]lint.RuleInfo) {
 	if len(stats) == 0 {
 		return
 	}
@@ -120,9 +121,19 @@ func (f *Friendly) printStatistics(w io.Writer, header string, stats map[string]
 	sort.Slice(data, func(i, j int) bool {
 		return data[i].failures > data[j].failures
 	})
+	described := false
+	for _, entry := range data {
+		if info[entry.name].Description != "" {
+			described = true
+		}
+	}
 	formatted := [][]string{}
 	for _, entry := range data {
-		formatted = append(formatted, []string{color.GreenString(fmt.Sprintf("%d", entry.failures)), entry.name})
+		row := []string{color.GreenString(fmt.Sprintf("%d", entry.failures)), entry.name}
+		if described {
+			row = append(row, info[entry.name].Description)
+		}
+		formatted = append(formatted, row)
 	}
 	fmt.Fprintln(w, header)
 	fmt.Fprintln(w, f.table(formatted))
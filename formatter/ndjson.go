@@ -18,6 +18,11 @@ func (*NDJSON) Name() string {
 	return "ndjson"
 }
 
+// Streaming returns true, each failure is formatted on its own line.
+func (*NDJSON) Streaming() bool {
+	return true
+}
+
 // Format formats the failures gotten from the lint.
 func (*NDJSON) Format(failures <-chan lint.Failure, config lint.Config) (string, error) {
 	var buf bytes.Buffer
@@ -3,6 +3,7 @@ package formatter
 import (
 	"bytes"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/chavacava/garif"
@@ -52,22 +53,25 @@ func newReviveRunLog(cfg lint.Config) *reviveRunLog {
 		cfg.Rules,
 	}
 
-	reviveLog.addRules(cfg.Rules)
+	reviveLog.addRules(cfg.Rules, cfg.RulesInfo)
 
 	return reviveLog
 }
 
-func (l *reviveRunLog) addRules(cfg map[string]lint.RuleConfig) {
-	for name, ruleCfg := range cfg {
-		rule := garif.NewRule(name).WithHelpUri(reviveSite + "/r#" + name)
-		setRuleProperties(rule, ruleCfg)
-		driver := l.run.Tool.Driver
+func (l *reviveRunLog) addRules(cfg map[string]lint.RuleConfig, info map[string]lint.RuleInfo) {
+	names := make([]string, 0, len(cfg))
+	for name := range cfg {
+		names = append(names, name)
+	}
+	sort.Strings(names)
 
-		if driver.Rules == nil {
-			driver.Rules = []*garif.ReportingDescriptor{rule}
-			return
+	driver := l.run.Tool.Driver
+	for _, name := range names {
+		rule := garif.NewRule(name).WithHelpUri(reviveSite + "/r#" + name)
+		setRuleProperties(rule, cfg[name])
+		if ruleInfo, ok := info[name]; ok {
+			setRuleHelp(rule, ruleInfo)
 		}
-
 		driver.Rules = append(driver.Rules, rule)
 	}
 }
@@ -89,6 +93,9 @@ func (l *reviveRunLog) AddResult(failure lint.Failure) {
 	result.Locations = append(result.Locations, location)
 	result.RuleId = failure.RuleName
 	result.Level = garif.ResultLevel(l.rules[failure.RuleName].Severity)
+	if failure.Severity != "" {
+		result.Level = garif.ResultLevel(failure.Severity)
+	}
 
 	l.run.Results = append(l.run.Results, result)
 }
@@ -105,3 +112,29 @@ func setRuleProperties(sarifRule *garif.ReportingDescriptor, lintRule lint.RuleC
 
 	sarifRule.WithProperties("severity", string(lintRule.Severity))
 }
+
+// setRuleHelp sets the descriptions and the help text of the rule from its metadata.
+func setRuleHelp(sarifRule *garif.ReportingDescriptor, info lint.RuleInfo) {
+	fullDescription := info.Description
+	if info.Rationale != "" {
+		fullDescription += " " + info.Rationale
+	}
+	sarifRule.ShortDescription = garif.NewMultiformatMessageString(info.Description)
+	sarifRule.FullDescription = garif.NewMultiformatMessageString(fullDescription)
+
+	var text, markdown strings.Builder
+	text.WriteString(fullDescription)
+	markdown.WriteString(fullDescription)
+	for _, example := range info.Examples {
+		if example.Bad != "" {
+			fmt.Fprintf(&text, "\n\nBad:\n%s", example.Bad)
+			fmt.Fprintf(&markdown, "\n\nBad:\n```go\n%s\n```", strings.TrimRight(example.Bad, "\n"))
+		}
+		if example.Good != "" {
+			fmt.Fprintf(&text, "\n\nGood:\n%s", example.Good)
+			fmt.Fprintf(&markdown, "\n\nGood:\n```go\n%s\n```", strings.TrimRight(example.Good, "\n"))
+		}
+	}
+	sarifRule.Help = garif.NewMultiformatMessageString(text.String())
+	sarifRule.Help.Markdown = markdown.String()
+}
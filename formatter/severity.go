@@ -3,6 +3,12 @@ package formatter
 import "github.com/mgechev/revive/lint"
 
 func severity(config lint.Config, failure lint.Failure) lint.Severity {
+	if failure.Category == lint.FailureCategoryError {
+		return lint.SeverityError
+	}
+	if failure.Severity != "" {
+		return failure.Severity
+	}
 	if config, ok := config.Rules[failure.RuleName]; ok && config.Severity == lint.SeverityError {
 		return lint.SeverityError
 	}
@@ -15,6 +15,7 @@ require (
 	github.com/spf13/afero v1.11.0
 	golang.org/x/mod v0.20.0
 	golang.org/x/tools v0.24.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
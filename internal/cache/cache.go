@@ -0,0 +1,99 @@
+// Package cache implements an on-disk cache of lint results.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/mgechev/revive/lint"
+)
+
+// dirName is the name of the cache directory in the user cache directory.
+const dirName = "revive"
+
+// DefaultDir returns the default cache directory,
+// i.e. $XDG_CACHE_HOME/revive on Unix systems.
+func DefaultDir() (string, error) {
+	userCacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(userCacheDir, dirName), nil
+}
+
+// Cache is a lint.Cache storing each entry in its own file under a directory.
+type Cache struct {
+	dir string
+	// salt is mixed into every key, e.g. to invalidate entries of another revive version
+	salt string
+}
+
+// New returns a cache storing its entries in dir.
+func New(dir, salt string) *Cache {
+	return &Cache{dir: dir, salt: salt}
+}
+
+var _ lint.Cache = (*Cache)(nil)
+
+// Get implements lint.Cache.
+// Missing or unreadable entries are reported as not found.
+func (c *Cache) Get(key string) ([]lint.Failure, bool) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	var failures []lint.Failure
+	if err := json.Unmarshal(data, &failures); err != nil {
+		return nil, false
+	}
+	return failures, true
+}
+
+// Put implements lint.Cache.
+// Errors are ignored: a failure to write an entry only results in a cache miss later.
+func (c *Cache) Put(key string, failures []lint.Failure) {
+	if failures == nil {
+		failures = []lint.Failure{}
+	}
+	data, err := json.Marshal(failures)
+	if err != nil {
+		return
+	}
+
+	path := c.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return
+	}
+
+	// write to a temporary file first, so that concurrent readers never see a partial entry
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return
+	}
+	_, writeErr := tmp.Write(data)
+	closeErr := tmp.Close()
+	if writeErr != nil || closeErr != nil || os.Rename(tmp.Name(), path) != nil {
+		os.Remove(tmp.Name())
+	}
+}
+
+func (c *Cache) path(key string) string {
+	hash := sha256.Sum256([]byte(c.salt + "\x00" + key))
+	name := hex.EncodeToString(hash[:])
+	return filepath.Join(c.dir, name[:2], name)
+}
+
+// Clean removes all the entries of the cache in dir.
+// It is not an error if dir does not exist.
+func Clean(dir string) error {
+	err := os.RemoveAll(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
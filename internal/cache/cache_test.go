@@ -0,0 +1,58 @@
+package cache
+
+import (
+	"go/token"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/mgechev/revive/lint"
+)
+
+func TestCache(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "revive")
+	failures := []lint.Failure{{
+		Failure:    "exported function Foo should have comment or be unexported",
+		RuleName:   "exported",
+		Category:   "comments",
+		Confidence: 1,
+		Position: lint.FailurePosition{
+			Start: token.Position{Filename: "a.go", Offset: 10, Line: 2, Column: 1},
+			End:   token.Position{Filename: "a.go", Offset: 20, Line: 2, Column: 11},
+		},
+		Edits: []lint.Edit{{NewText: "any"}},
+	}}
+
+	c := New(dir, "v1")
+	if _, ok := c.Get("key"); ok {
+		t.Fatal("expected a miss on an empty cache")
+	}
+
+	c.Put("key", failures)
+	got, ok := c.Get("key")
+	if !ok {
+		t.Fatal("expected a hit after Put")
+	}
+	if !reflect.DeepEqual(got, failures) {
+		t.Errorf("got %+v, want %+v", got, failures)
+	}
+
+	c.Put("clean", nil)
+	if got, ok := c.Get("clean"); !ok || len(got) != 0 {
+		t.Errorf("expected a hit without failures, got %v, %v", got, ok)
+	}
+
+	if _, ok := New(dir, "v2").Get("key"); ok {
+		t.Error("expected a miss with another salt")
+	}
+
+	if err := Clean(dir); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.Get("key"); ok {
+		t.Error("expected a miss after Clean")
+	}
+	if err := Clean(dir); err != nil {
+		t.Errorf("cleaning a missing cache: %v", err)
+	}
+}
@@ -0,0 +1,131 @@
+package diff
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Changes are the lines added or changed, in their new version, of the files of a patch.
+// Files are indexed by their cleaned, slash-separated, path.
+type Changes map[string]map[int]bool
+
+// Contains returns true if the given line of the named file was added or changed.
+func (c Changes) Contains(filename string, line int) bool {
+	return c[normalizePath(filename)][line]
+}
+
+// AddFile marks all the lines of the named file as added.
+func (c Changes) AddFile(filename string, lines int) {
+	added := map[int]bool{}
+	for i := 1; i <= lines; i++ {
+		added[i] = true
+	}
+	c[normalizePath(filename)] = added
+}
+
+var hunkHeaderRE = regexp.MustCompile(`^@@ -\d+(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+
+// Parse returns the changes described by the given unified diff,
+// as produced by "git diff" or "diff -u".
+// The "b/" prefix of the paths of git diffs is removed.
+func Parse(patch []byte) (Changes, error) {
+	changes := Changes{}
+
+	var (
+		current map[int]bool // added lines of the current file, nil if the file is deleted
+		line    int          // number, in the new version, of the next line of the hunk
+		oldLeft int          // lines of the old version remaining in the current hunk
+		newLeft int          // lines of the new version remaining in the current hunk
+		inHunk  bool
+	)
+	sc := bufio.NewScanner(bytes.NewReader(patch))
+	sc.Buffer(nil, 1024*1024)
+	for n := 1; sc.Scan(); n++ {
+		text := sc.Text()
+		if inHunk {
+			// lines of the hunk body are never file headers, even "+++ …" ones
+			switch {
+			case strings.HasPrefix(text, "+"):
+				if current != nil {
+					current[line] = true
+				}
+				line++
+				newLeft--
+			case strings.HasPrefix(text, " "), text == "":
+				line++
+				oldLeft--
+				newLeft--
+			case strings.HasPrefix(text, "-"):
+				oldLeft--
+			case strings.HasPrefix(text, `\`):
+				// "\ No newline at end of file"
+			default:
+				inHunk = false // truncated hunk, the line is a header
+			}
+			if inHunk {
+				inHunk = oldLeft > 0 || newLeft > 0
+				continue
+			}
+		}
+
+		switch {
+		case strings.HasPrefix(text, "+++ "):
+			name, err := patchPath(text[len("+++ "):])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", n, err)
+			}
+			current = nil
+			if name != "/dev/null" {
+				current = map[int]bool{}
+				changes[normalizePath(name)] = current
+			}
+		case strings.HasPrefix(text, "@@ "):
+			match := hunkHeaderRE.FindStringSubmatch(text)
+			if match == nil {
+				return nil, fmt.Errorf("line %d: invalid hunk header %q", n, text)
+			}
+			line, _ = strconv.Atoi(match[2])
+			oldLeft, newLeft = hunkLength(match[1]), hunkLength(match[3])
+			inHunk = oldLeft > 0 || newLeft > 0
+		default:
+			// file header (e.g. "diff --git", "index", "--- a/file")
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// hunkLength returns the number of lines of a range of a hunk header, 1 if omitted.
+func hunkLength(s string) int {
+	if s == "" {
+		return 1
+	}
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+// patchPath returns the path of a "+++" header line of a patch.
+func patchPath(s string) (string, error) {
+	if strings.HasPrefix(s, `"`) {
+		unquoted, err := strconv.Unquote(s)
+		if err != nil {
+			return "", fmt.Errorf("invalid quoted path %s", s)
+		}
+		s = unquoted
+	} else if i := strings.IndexByte(s, '\t'); i >= 0 {
+		s = s[:i] // timestamp of "diff -u"
+	}
+	return strings.TrimPrefix(s, "b/"), nil
+}
+
+func normalizePath(path string) string {
+	return filepath.ToSlash(filepath.Clean(path))
+}
@@ -0,0 +1,122 @@
+package diff
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		patch string
+		want  Changes
+	}{
+		{
+			name: "git diff",
+			patch: `diff --git a/foo/a.go b/foo/a.go
+index 3b18e51..a4f3a2c 100644
+--- a/foo/a.go
++++ b/foo/a.go
+@@ -1,4 +1,5 @@
+ package foo
+-var a = 1
++var a = 2
++var b = 3
+ 
+ func f() {}
+@@ -10,0 +12,2 @@ func g() {
++	x()
++	y()
+diff --git a/b.go b/b.go
+new file mode 100644
+--- /dev/null
++++ b/b.go
+@@ -0,0 +1,2 @@
++package foo
++
+\ No newline at end of file
+diff --git a/c.go b/c.go
+deleted file mode 100644
+--- a/c.go
++++ /dev/null
+@@ -1 +0,0 @@
+-package foo
+`,
+			want: Changes{
+				"foo/a.go": {2: true, 3: true, 12: true, 13: true},
+				"b.go":     {1: true, 2: true},
+			},
+		},
+		{
+			name: "diff -u",
+			patch: `--- old/a.go	2024-01-01 10:00:00.000000000 +0100
++++ ./a.go	2024-01-02 10:00:00.000000000 +0100
+@@ -3 +3 @@
+-var a = 1
++var a = 2
+`,
+			want: Changes{"a.go": {3: true}},
+		},
+		{
+			name: "quoted path",
+			patch: `--- "a/with space.go"
++++ "b/with space.go"
+@@ -1 +1 @@
+-package a
++package b
+`,
+			want: Changes{"with space.go": {1: true}},
+		},
+		{
+			name: "added lines looking like headers",
+			patch: `--- a/a.go
++++ b/a.go
+@@ -1,2 +1,4 @@
+ package a
++++ b/not-a-file.go
++--- a/not-a-file.go
+ var a = 1
+--- a/b.go
++++ b/b.go
+@@ -1 +1 @@
+-package a
++package b
+`,
+			want: Changes{"a.go": {2: true, 3: true}, "b.go": {1: true}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse([]byte(tt.patch))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseInvalidHunkHeader(t *testing.T) {
+	_, err := Parse([]byte("+++ b/a.go\n@@ -1 +x @@\n"))
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestChangesContains(t *testing.T) {
+	changes := Changes{}
+	changes.AddFile("foo/a.go", 3)
+
+	if !changes.Contains("./foo/a.go", 3) {
+		t.Error("expected line 3 of ./foo/a.go to be changed")
+	}
+	if changes.Contains("foo/a.go", 4) {
+		t.Error("expected line 4 of foo/a.go not to be changed")
+	}
+	if changes.Contains("a.go", 1) {
+		t.Error("expected a.go not to be changed")
+	}
+}
@@ -0,0 +1,59 @@
+package ifelse
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+
+	"github.com/mgechev/revive/lint"
+)
+
+// outdentElse returns the edits dropping the "else" of the given if statement
+// and outdenting its block, or nil if the transformation can not be safely applied.
+func outdentElse(file *lint.File, ifStmt *ast.IfStmt) []lint.Edit {
+	elseBlock, ok := ifStmt.Else.(*ast.BlockStmt)
+	if !ok || hasMultilineRawString(elseBlock) {
+		return nil
+	}
+
+	src := file.Content()
+	lbrace := file.ToPosition(elseBlock.Lbrace).Offset
+	rbrace := file.ToPosition(elseBlock.Rbrace).Offset
+	if lbrace < 0 || rbrace > len(src) || lbrace >= rbrace {
+		return nil
+	}
+
+	body := string(src[lbrace+1 : rbrace])
+	if strings.TrimSpace(body) == "" {
+		return []lint.Edit{lint.ToEdit(ifStmt.Body.End(), elseBlock.End(), "", file)}
+	}
+
+	if !strings.HasPrefix(body, "\n") {
+		return nil // not gofmt-ed, e.g. a comment follows the opening brace
+	}
+
+	// drop the indentation of the closing brace line and its line break
+	body = strings.TrimRight(body, " \t")
+	body = strings.TrimSuffix(body, "\n")
+
+	lines := strings.Split(body, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimPrefix(line, "\t")
+	}
+
+	return []lint.Edit{lint.ToEdit(ifStmt.Body.End(), elseBlock.End(), strings.Join(lines, "\n"), file)}
+}
+
+// hasMultilineRawString returns true if the given node contains a raw string literal
+// spanning several lines, whose content would be altered by outdenting.
+func hasMultilineRawString(node ast.Node) bool {
+	found := false
+	ast.Inspect(node, func(n ast.Node) bool {
+		lit, ok := n.(*ast.BasicLit)
+		if ok && lit.Kind == token.STRING && strings.HasPrefix(lit.Value, "`") && strings.Contains(lit.Value, "\n") {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
@@ -12,9 +12,12 @@ type Rule interface {
 	CheckIfElse(chain Chain, args Args) (failMsg string)
 }
 
-// Apply evaluates the given Rule on if-else chains found within the given AST,
+// Apply evaluates the given Rule on if-else chains found within the AST of the given file,
 // and returns the failures.
 //
+// When the target is the "else", failures come with a fix dropping the "else"
+// and outdenting its block, provided that it does not change variable scopes.
+//
 // Note that in if-else chain with multiple "if" blocks, only the *last* one is checked,
 // that is to say, given:
 //
@@ -28,14 +31,14 @@ type Rule interface {
 //
 // Only the block following "bar" is linted. This is because the rules that use this function
 // do not presently have anything to say about earlier blocks in the chain.
-func Apply(rule Rule, node ast.Node, target Target, args lint.Arguments) []lint.Failure {
-	v := &visitor{rule: rule, target: target}
+func Apply(rule Rule, file *lint.File, target Target, args lint.Arguments) []lint.Failure {
+	v := &visitor{rule: rule, target: target, file: file}
 	for _, arg := range args {
 		if arg == PreserveScope {
 			v.args.PreserveScope = true
 		}
 	}
-	ast.Walk(v, node)
+	ast.Walk(v, file.AST)
 	return v.failures
 }
 
@@ -44,6 +47,7 @@ type visitor struct {
 	target   Target
 	rule     Rule
 	args     Args
+	file     *lint.File
 }
 
 func (v *visitor) Visit(node ast.Node) ast.Visitor {
@@ -93,11 +97,15 @@ func (v *visitor) visitChain(ifStmt *ast.IfStmt, chain Chain) {
 				// onto its own line in case the body references it
 				failMsg += " (move short variable declaration to its own line if necessary)"
 			}
-			v.failures = append(v.failures, lint.Failure{
+			failure := lint.Failure{
 				Confidence: 1,
 				Node:       v.target.node(ifStmt),
 				Failure:    failMsg,
-			})
+			}
+			if v.target == TargetElse && !chain.HasInitializer && !chain.Else.HasDecls {
+				failure.Edits = outdentElse(v.file, ifStmt)
+			}
+			v.failures = append(v.failures, failure)
 		}
 	default:
 		panic("invalid node type for else")
@@ -0,0 +1,535 @@
+// Package lsp implements a Language Server Protocol server publishing the failures of revive as diagnostics.
+package lsp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mgechev/revive/config"
+	"github.com/mgechev/revive/lint"
+	"github.com/mgechev/revive/revivelib"
+)
+
+// diagnosticSource is the source of the published diagnostics.
+const diagnosticSource = "revive"
+
+// Loader returns the linter to use, configured with the current configuration
+// of the workspace whose root directory is root, empty if the client sent none.
+// It is called when the server is initialized and again when a configuration file changes.
+type Loader func(root string) (*revivelib.Revive, error)
+
+// Server is a Language Server Protocol server linting the open Go documents.
+// Each change of a document lints its package again, reading the open documents
+// instead of their files, and publishes the failures of the open documents as diagnostics.
+// The suggested fixes of the failures are available as code actions.
+type Server struct {
+	load Loader
+	// root is the root directory of the workspace, given by the client on initialization
+	root   string
+	revive *revivelib.Revive
+	conn   *conn
+	// configFiles are the base names of the configuration files whose change reloads the configuration
+	configFiles map[string]bool
+	// documents are the open documents by URI
+	documents map[string]*document
+	// watchConfig is whether the client watches the configuration files for the server
+	watchConfig bool
+	shutdown    bool
+}
+
+// document is an open document.
+type document struct {
+	path    string
+	content []byte
+	// lines are the lines of the content, without line terminators
+	lines [][]byte
+	// failures are the failures published for the document
+	failures []lint.Failure
+}
+
+// errExit is returned by handle on the exit notification.
+var errExit = errors.New("exit")
+
+// NewServer returns a server using the linter returned by load.
+// Besides the files named as config.FileNames, a change of one of configFiles,
+// e.g. the configuration file given on the command line, reloads the configuration.
+func NewServer(load Loader, configFiles ...string) *Server {
+	names := map[string]bool{}
+	for _, name := range append(config.FileNames(), configFiles...) {
+		names[filepath.Base(name)] = true
+	}
+	return &Server{
+		load:        load,
+		configFiles: names,
+		documents:   map[string]*document{},
+	}
+}
+
+// Serve handles the messages read from r, writing its messages to w,
+// until the exit notification or the end of r.
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	s.conn = newConn(r, w)
+
+	for ctx.Err() == nil {
+		msg, err := s.conn.read()
+		var rerr *responseError
+		switch {
+		case errors.Is(err, io.EOF):
+			return nil
+		case errors.As(err, &rerr):
+			null := json.RawMessage("null")
+			if err := s.conn.reply(&null, nil, rerr); err != nil {
+				return err
+			}
+			continue
+		case err != nil:
+			return err
+		}
+
+		err = s.handle(ctx, msg)
+		if errors.Is(err, errExit) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+// handle handles a message, returning an error only if the connection is broken.
+func (s *Server) handle(ctx context.Context, msg *message) error {
+	if msg.isResponse() {
+		// the responses to the requests of the server are not needed
+		return nil
+	}
+	if msg.Method == "exit" {
+		return errExit
+	}
+	if s.shutdown {
+		if msg.isNotification() {
+			return nil
+		}
+		return s.conn.reply(msg.ID, nil, &responseError{Code: codeInvalidRequest, Message: "the server is shut down"})
+	}
+	if s.revive == nil && msg.Method != "initialize" {
+		if msg.isNotification() {
+			return nil
+		}
+		return s.conn.reply(msg.ID, nil, &responseError{Code: codeServerNotInitialized, Message: "the server is not initialized"})
+	}
+
+	result, err := s.dispatch(ctx, msg)
+	if msg.isNotification() {
+		if err != nil {
+			return s.showError(err)
+		}
+		return nil
+	}
+
+	var rerr *responseError
+	if err != nil && !errors.As(err, &rerr) {
+		rerr = &responseError{Code: codeInternalError, Message: err.Error()}
+	}
+	return s.conn.reply(msg.ID, result, rerr)
+}
+
+func (s *Server) dispatch(ctx context.Context, msg *message) (any, error) {
+	switch msg.Method {
+	case "initialize":
+		return s.initialize(msg.Params)
+	case "initialized":
+		return nil, s.initialized()
+	case "shutdown":
+		s.shutdown = true
+		return nil, nil
+	case "textDocument/didOpen":
+		var params didOpenParams
+		if err := unmarshalParams(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		return nil, s.didOpen(ctx, params)
+	case "textDocument/didChange":
+		var params didChangeParams
+		if err := unmarshalParams(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		return nil, s.didChange(ctx, params)
+	case "textDocument/didSave":
+		var params didSaveParams
+		if err := unmarshalParams(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		return nil, s.didSave(ctx, params)
+	case "textDocument/didClose":
+		var params didCloseParams
+		if err := unmarshalParams(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		return nil, s.didClose(ctx, params)
+	case "workspace/didChangeWatchedFiles":
+		var params didChangeWatchedFilesParams
+		if err := unmarshalParams(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		return nil, s.didChangeWatchedFiles(ctx, params)
+	case "textDocument/codeAction":
+		var params codeActionParams
+		if err := unmarshalParams(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		return s.codeAction(params), nil
+	default:
+		return nil, &responseError{Code: codeMethodNotFound, Message: "method not found: " + msg.Method}
+	}
+}
+
+func unmarshalParams(data json.RawMessage, params any) error {
+	if err := json.Unmarshal(data, params); err != nil {
+		return &responseError{Code: codeInvalidParams, Message: err.Error()}
+	}
+	return nil
+}
+
+func (s *Server) initialize(data json.RawMessage) (any, error) {
+	var params struct {
+		RootPath         string            `json:"rootPath"`
+		RootURI          string            `json:"rootUri"`
+		WorkspaceFolders []workspaceFolder `json:"workspaceFolders"`
+		Capabilities     struct {
+			Workspace struct {
+				DidChangeWatchedFiles struct {
+					DynamicRegistration bool `json:"dynamicRegistration"`
+				} `json:"didChangeWatchedFiles"`
+			} `json:"workspace"`
+		} `json:"capabilities"`
+	}
+	if err := unmarshalParams(data, &params); err != nil {
+		return nil, err
+	}
+	s.watchConfig = params.Capabilities.Workspace.DidChangeWatchedFiles.DynamicRegistration
+
+	// the configuration of the first workspace folder applies to the others too
+	rootURI := params.RootURI
+	if len(params.WorkspaceFolders) > 0 {
+		rootURI = params.WorkspaceFolders[0].URI
+	}
+	s.root = params.RootPath // deprecated in favor of rootUri
+	if rootURI != "" {
+		root, err := uriToPath(rootURI)
+		if err != nil {
+			return nil, &responseError{Code: codeInvalidParams, Message: err.Error()}
+		}
+		s.root = root
+	}
+	revive, err := s.load(s.root)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load the configuration: %w", err)
+	}
+	s.revive = revive
+
+	return initializeResult{
+		Capabilities: serverCapabilities{
+			TextDocumentSync: textDocumentSyncOptions{
+				OpenClose: true,
+				Change:    textDocumentSyncFull,
+				Save:      saveOptions{IncludeText: true},
+			},
+			CodeActionProvider: true,
+		},
+		ServerInfo: serverInfo{Name: "revive"},
+	}, nil
+}
+
+// initialized asks the client to watch the configuration files, if it can.
+func (s *Server) initialized() error {
+	if !s.watchConfig {
+		return nil
+	}
+	watchers := make([]fileSystemWatcher, 0, len(s.configFiles))
+	for name := range s.configFiles {
+		watchers = append(watchers, fileSystemWatcher{GlobPattern: "**/" + name})
+	}
+	sort.Slice(watchers, func(i, j int) bool { return watchers[i].GlobPattern < watchers[j].GlobPattern })
+
+	return s.conn.call("client/registerCapability", registrationParams{Registrations: []registration{{
+		ID:              "revive-config",
+		Method:          "workspace/didChangeWatchedFiles",
+		RegisterOptions: didChangeWatchedFilesRegistrationOptions{Watchers: watchers},
+	}}})
+}
+
+func (s *Server) didOpen(ctx context.Context, params didOpenParams) error {
+	path, err := uriToPath(params.TextDocument.URI)
+	if err != nil || !isGoFile(path) {
+		// e.g. an unsaved document, without file
+		return nil
+	}
+	doc := &document{path: path}
+	s.documents[params.TextDocument.URI] = doc
+	s.setContent(doc, []byte(params.TextDocument.Text))
+	return s.lint(ctx, doc.path)
+}
+
+func (s *Server) didChange(ctx context.Context, params didChangeParams) error {
+	doc, ok := s.documents[params.TextDocument.URI]
+	if !ok || len(params.ContentChanges) == 0 {
+		return nil
+	}
+	// the content is synchronized in full, the last change is the whole document
+	s.setContent(doc, []byte(params.ContentChanges[len(params.ContentChanges)-1].Text))
+	return s.lint(ctx, doc.path)
+}
+
+func (s *Server) didSave(ctx context.Context, params didSaveParams) error {
+	path, err := uriToPath(params.TextDocument.URI)
+	if err != nil {
+		return err
+	}
+	if s.configFiles[filepath.Base(path)] {
+		return s.reload(ctx)
+	}
+
+	doc, ok := s.documents[params.TextDocument.URI]
+	if !ok {
+		return nil
+	}
+	if params.Text != nil {
+		s.setContent(doc, []byte(*params.Text))
+	}
+	return s.lint(ctx, doc.path)
+}
+
+func (s *Server) didClose(ctx context.Context, params didCloseParams) error {
+	uri := params.TextDocument.URI
+	doc, ok := s.documents[uri]
+	if !ok {
+		return nil
+	}
+	delete(s.documents, uri)
+	s.revive.SetOverlay(doc.path, nil)
+	if err := s.conn.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{URI: uri, Diagnostics: []diagnostic{}}); err != nil {
+		return err
+	}
+
+	// the file on disk replaces the closed document in its package
+	for _, other := range s.documents {
+		if filepath.Dir(other.path) == filepath.Dir(doc.path) {
+			return s.lint(ctx, other.path)
+		}
+	}
+	return nil
+}
+
+func (s *Server) didChangeWatchedFiles(ctx context.Context, params didChangeWatchedFilesParams) error {
+	for _, change := range params.Changes {
+		path, err := uriToPath(change.URI)
+		if err == nil && s.configFiles[filepath.Base(path)] {
+			return s.reload(ctx)
+		}
+	}
+	return nil
+}
+
+// reload loads the linter again and lints the open documents with it.
+// The current linter is kept if the configuration cannot be loaded.
+func (s *Server) reload(ctx context.Context) error {
+	revive, err := s.load(s.root)
+	if err != nil {
+		return fmt.Errorf("cannot reload the configuration: %w", err)
+	}
+	for _, doc := range s.documents {
+		revive.SetOverlay(doc.path, doc.content)
+	}
+	s.revive = revive
+
+	linted := map[string]bool{}
+	for _, uri := range s.sortedURIs() {
+		doc := s.documents[uri]
+		dir := filepath.Dir(doc.path)
+		if linted[dir] {
+			continue
+		}
+		linted[dir] = true
+		if err := s.lint(ctx, doc.path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Server) setContent(doc *document, content []byte) {
+	doc.content = content
+	doc.lines = bytes.Split(content, []byte("\n"))
+	for i, line := range doc.lines {
+		doc.lines[i] = bytes.TrimSuffix(line, []byte("\r"))
+	}
+	s.revive.SetOverlay(doc.path, content)
+}
+
+// lint lints the package of the file at path and publishes the diagnostics of its open documents.
+func (s *Server) lint(ctx context.Context, path string) error {
+	failures, err := s.revive.LintPackageOf(ctx, path)
+	if err != nil {
+		return err
+	}
+	byFile := map[string][]lint.Failure{}
+	for failure := range failures {
+		if failure.Confidence < s.revive.Confidence(failure) {
+			continue
+		}
+		filename := filepath.Clean(failure.GetFilename())
+		byFile[filename] = append(byFile[filename], failure)
+	}
+
+	for _, uri := range s.sortedURIs() {
+		doc := s.documents[uri]
+		if filepath.Dir(doc.path) != filepath.Dir(path) {
+			continue
+		}
+		doc.failures = byFile[doc.path]
+		sort.SliceStable(doc.failures, func(i, j int) bool {
+			a, b := doc.failures[i].Position.Start, doc.failures[j].Position.Start
+			return a.Line < b.Line || a.Line == b.Line && a.Column < b.Column
+		})
+
+		diagnostics := make([]diagnostic, len(doc.failures))
+		for i, failure := range doc.failures {
+			diagnostics[i] = s.diagnostic(doc, failure)
+		}
+		if err := s.conn.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{URI: uri, Diagnostics: diagnostics}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Server) diagnostic(doc *document, failure lint.Failure) diagnostic {
+	severity := severityWarning
+	if s.revive.Severity(failure) == lint.SeverityError {
+		severity = severityError
+	}
+	return diagnostic{
+		Range:    doc.rangeOf(failure.Position),
+		Severity: severity,
+		Code:     failure.RuleName,
+		Source:   diagnosticSource,
+		Message:  failure.Failure,
+	}
+}
+
+// codeAction returns the fixes of the failures of the document overlapping the range.
+func (s *Server) codeAction(params codeActionParams) []codeAction {
+	actions := []codeAction{}
+	uri := params.TextDocument.URI
+	doc, ok := s.documents[uri]
+	if !ok {
+		return actions
+	}
+
+	for _, failure := range doc.failures {
+		if !failure.IsFixable() {
+			continue
+		}
+		diag := s.diagnostic(doc, failure)
+		if !overlaps(diag.Range, params.Range) {
+			continue
+		}
+		edits := make([]textEdit, len(failure.Edits))
+		for i, edit := range failure.Edits {
+			edits[i] = textEdit{Range: doc.rangeOf(edit.Position), NewText: edit.NewText}
+		}
+		actions = append(actions, codeAction{
+			Title:       failure.Failure,
+			Kind:        codeActionQuickFix,
+			Diagnostics: []diagnostic{diag},
+			Edit:        &workspaceEdit{Changes: map[string][]textEdit{uri: edits}},
+		})
+	}
+	return actions
+}
+
+func (s *Server) showError(err error) error {
+	return s.conn.notify("window/showMessage", showMessageParams{Type: messageTypeError, Message: "revive: " + err.Error()})
+}
+
+func (s *Server) sortedURIs() []string {
+	uris := make([]string, 0, len(s.documents))
+	for uri := range s.documents {
+		uris = append(uris, uri)
+	}
+	sort.Strings(uris)
+	return uris
+}
+
+// rangeOf returns the range of the failure position, empty at its start if it has no end.
+func (d *document) rangeOf(pos lint.FailurePosition) rangeT {
+	start := d.position(pos.Start.Line, pos.Start.Column)
+	if pos.End.Line == 0 {
+		return rangeT{Start: start, End: start}
+	}
+	return rangeT{Start: start, End: d.position(pos.End.Line, pos.End.Column)}
+}
+
+// position converts a one-based line and byte column to an LSP position.
+func (d *document) position(line, column int) position {
+	if line < 1 || line > len(d.lines) {
+		return position{}
+	}
+	text := d.lines[line-1]
+	column = max(0, min(column-1, len(text)))
+	return position{Line: line - 1, Character: utf16Len(text[:column])}
+}
+
+// utf16Len returns the number of UTF-16 code units encoding b.
+func utf16Len(b []byte) int {
+	n := 0
+	for len(b) > 0 {
+		r, size := utf8.DecodeRune(b)
+		b = b[size:]
+		n++
+		if r > 0xFFFF {
+			n++
+		}
+	}
+	return n
+}
+
+func overlaps(a, b rangeT) bool {
+	return !before(a.End, b.Start) && !before(b.End, a.Start)
+}
+
+func before(a, b position) bool {
+	return a.Line < b.Line || a.Line == b.Line && a.Character < b.Character
+}
+
+func isGoFile(path string) bool {
+	return strings.HasSuffix(path, ".go")
+}
+
+// uriToPath returns the path of a file URI.
+func uriToPath(uri string) (string, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme != "file" {
+		return "", fmt.Errorf("unsupported URI %q, only file URIs are supported", uri)
+	}
+	path := u.Path
+	if runtime.GOOS == "windows" {
+		// file:///C:/dir/file.go
+		path = strings.TrimPrefix(path, "/")
+	}
+	return filepath.Clean(filepath.FromSlash(path)), nil
+}
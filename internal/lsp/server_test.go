@@ -0,0 +1,289 @@
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/mgechev/revive/config"
+	"github.com/mgechev/revive/lint"
+	"github.com/mgechev/revive/revivelib"
+)
+
+// client is an in-process JSON-RPC client of a server.
+type client struct {
+	t    *testing.T
+	conn *conn
+	// messages are the messages sent by the server
+	messages chan *message
+	nextID   int
+}
+
+func newClient(t *testing.T, server *Server) (*client, <-chan error) {
+	t.Helper()
+	clientReader, serverWriter := io.Pipe()
+	serverReader, clientWriter := io.Pipe()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- server.Serve(context.Background(), serverReader, serverWriter)
+		serverWriter.Close()
+	}()
+
+	c := &client{t: t, conn: newConn(clientReader, clientWriter), messages: make(chan *message, 16)}
+	go func() {
+		defer close(c.messages)
+		for {
+			msg, err := c.conn.read()
+			if err != nil {
+				return
+			}
+			c.messages <- msg
+		}
+	}()
+	t.Cleanup(func() { clientWriter.Close() })
+	return c, done
+}
+
+// next returns the next message sent by the server.
+func (c *client) next() *message {
+	c.t.Helper()
+	select {
+	case msg, ok := <-c.messages:
+		if !ok {
+			c.t.Fatal("the connection is closed")
+		}
+		return msg
+	case <-time.After(10 * time.Second):
+		c.t.Fatal("timeout waiting for a message of the server")
+		return nil
+	}
+}
+
+func (c *client) notify(method string, params any) {
+	c.t.Helper()
+	if err := c.conn.notify(method, params); err != nil {
+		c.t.Fatal(err)
+	}
+}
+
+// call sends a request and unmarshals the result of its response into result.
+func (c *client) call(method string, params, result any) {
+	c.t.Helper()
+	data, err := json.Marshal(params)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	c.nextID++
+	id := json.RawMessage(strconv.Itoa(c.nextID))
+	if err := c.conn.write(&message{ID: &id, Method: method, Params: data}); err != nil {
+		c.t.Fatal(err)
+	}
+
+	msg := c.next()
+	if !msg.isResponse() || string(*msg.ID) != string(id) {
+		c.t.Fatalf("got message %+v, want the response to %s", msg, method)
+	}
+	if msg.Error != nil {
+		c.t.Fatalf("%s failed: %v", method, msg.Error)
+	}
+	if err := json.Unmarshal(msg.Result, result); err != nil {
+		c.t.Fatal(err)
+	}
+}
+
+// diagnostics returns the next diagnostics published by the server.
+func (c *client) diagnostics() publishDiagnosticsParams {
+	c.t.Helper()
+	msg := c.next()
+	if msg.Method != "textDocument/publishDiagnostics" {
+		c.t.Fatalf("got message %+v, want published diagnostics", msg)
+	}
+	var params publishDiagnosticsParams
+	if err := json.Unmarshal(msg.Params, &params); err != nil {
+		c.t.Fatal(err)
+	}
+	return params
+}
+
+func TestServer(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "revive.toml")
+	writeFile(t, configPath, "[rule.increment-decrement]\n")
+	// the document uses a function declared in another file of its package
+	writeFile(t, filepath.Join(dir, "helper.go"), "package p\n\nfunc helper() int { return 1 }\n")
+	path := filepath.Join(dir, "main.go")
+	uri := pathToURI(path)
+
+	server := NewServer(func(root string) (*revivelib.Revive, error) {
+		// the configuration is that of the workspace, not of the working directory
+		conf, err := config.GetConfig(filepath.Join(root, "revive.toml"))
+		if err != nil {
+			return nil, err
+		}
+		conf.TypeCheck.ReportErrors = true
+		return revivelib.New(conf, false, 0)
+	})
+	c, done := newClient(t, server)
+
+	var initResult initializeResult
+	c.call("initialize", map[string]any{
+		"rootUri": pathToURI(dir),
+		"capabilities": map[string]any{
+			"workspace": map[string]any{"didChangeWatchedFiles": map[string]any{"dynamicRegistration": true}},
+		},
+	}, &initResult)
+	if !initResult.Capabilities.CodeActionProvider || initResult.Capabilities.TextDocumentSync.Change != textDocumentSyncFull {
+		t.Errorf("unexpected capabilities %+v", initResult.Capabilities)
+	}
+
+	c.notify("initialized", struct{}{})
+	if msg := c.next(); msg.Method != "client/registerCapability" {
+		t.Fatalf("got message %+v, want the registration of the configuration watchers", msg)
+	}
+
+	// the emoji takes 4 bytes and 2 UTF-16 code units
+	const src = "package p\n\nfunc f() {\n\tx := helper()\n\t/*😀*/ x += 1\n\t_ = x\n}\n"
+	failureRange := rangeT{Start: position{Line: 4, Character: 8}, End: position{Line: 4, Character: 14}}
+	c.notify("textDocument/didOpen", didOpenParams{TextDocument: textDocumentItem{URI: uri, Version: 1, Text: src}})
+	want := publishDiagnosticsParams{URI: uri, Diagnostics: []diagnostic{{
+		Range:    failureRange,
+		Severity: severityWarning,
+		Code:     "increment-decrement",
+		Source:   diagnosticSource,
+		Message:  "should replace x += 1 with x++",
+	}}}
+	if got := c.diagnostics(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got diagnostics %+v, want %+v", got, want)
+	}
+
+	var actions []codeAction
+	c.call("textDocument/codeAction", codeActionParams{
+		TextDocument: textDocumentIdentifier{URI: uri},
+		Range:        rangeT{Start: position{Line: 4, Character: 10}, End: position{Line: 4, Character: 10}},
+	}, &actions)
+	wantEdits := map[string][]textEdit{uri: {{Range: failureRange, NewText: "x++"}}}
+	if len(actions) != 1 || actions[0].Kind != codeActionQuickFix || !reflect.DeepEqual(actions[0].Edit.Changes, wantEdits) {
+		t.Errorf("got code actions %+v, want the fix of the failure", actions)
+	}
+
+	c.notify("textDocument/didChange", map[string]any{
+		"textDocument":   map[string]any{"uri": uri, "version": 2},
+		"contentChanges": []map[string]string{{"text": "package p\n\nvar x = helper()\n"}},
+	})
+	if got := c.diagnostics(); len(got.Diagnostics) != 0 {
+		t.Errorf("got diagnostics %+v after the fix, want none", got.Diagnostics)
+	}
+
+	c.notify("textDocument/didChange", map[string]any{
+		"textDocument":   map[string]any{"uri": uri, "version": 3},
+		"contentChanges": []map[string]string{{"text": src}},
+	})
+	c.diagnostics()
+
+	writeFile(t, configPath, "[rule.increment-decrement]\nseverity = \"error\"\n")
+	configURI := pathToURI(configPath)
+	c.notify("workspace/didChangeWatchedFiles", map[string]any{"changes": []map[string]any{{"uri": configURI, "type": 2}}})
+	if got := c.diagnostics(); len(got.Diagnostics) != 1 || got.Diagnostics[0].Severity != severityError {
+		t.Errorf("got diagnostics %+v after the configuration reload, want an error", got.Diagnostics)
+	}
+
+	c.notify("textDocument/didClose", didCloseParams{TextDocument: textDocumentIdentifier{URI: uri}})
+	if got := c.diagnostics(); len(got.Diagnostics) != 0 {
+		t.Errorf("got diagnostics %+v for the closed document, want none", got.Diagnostics)
+	}
+
+	var result any
+	c.call("shutdown", nil, &result)
+	c.notify("exit", nil)
+	if err := <-done; err != nil {
+		t.Errorf("Serve returned %v", err)
+	}
+}
+
+func TestServerNestedConfig(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "revive.toml"), "[rule.increment-decrement]\n")
+	dir := filepath.Join(root, "strict")
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(dir, "revive.toml"), "confidence = 0.9\n[rule.unexported-naming]\nseverity = \"error\"\n")
+	path := filepath.Join(dir, "main.go")
+
+	server := NewServer(func(root string) (*revivelib.Revive, error) {
+		configPath := filepath.Join(root, "revive.toml")
+		conf, err := config.GetConfig(configPath)
+		if err != nil {
+			return nil, err
+		}
+		revive, err := revivelib.New(conf, false, 0)
+		if err != nil {
+			return nil, err
+		}
+		revive.EnableNestedConfig(configPath)
+		return revive, nil
+	})
+	c, _ := newClient(t, server)
+
+	var initResult initializeResult
+	c.call("initialize", map[string]any{
+		"workspaceFolders": []map[string]any{{"uri": pathToURI(root), "name": "root"}},
+	}, &initResult)
+
+	// the failure of increment-decrement has a confidence of 0.8
+	const src = "package p\n\nfunc F(I int) int {\n\tI += 1\n\treturn I\n}\n"
+	c.notify("textDocument/didOpen", didOpenParams{TextDocument: textDocumentItem{URI: pathToURI(path), Version: 1, Text: src}})
+	got := c.diagnostics()
+	severities := map[string]int{}
+	for _, d := range got.Diagnostics {
+		severities[d.Code] = d.Severity
+	}
+	want := map[string]int{"unexported-naming": severityError}
+	if !reflect.DeepEqual(severities, want) {
+		t.Errorf("got diagnostics %+v, want those of the nested configuration %v", got.Diagnostics, want)
+	}
+}
+
+func TestServerUnknownMethod(t *testing.T) {
+	server := NewServer(func(string) (*revivelib.Revive, error) {
+		return revivelib.New(&lint.Config{Rules: lint.RulesConfig{}}, false, 0)
+	})
+	c, _ := newClient(t, server)
+
+	id := json.RawMessage("1")
+	if err := c.conn.write(&message{ID: &id, Method: "textDocument/hover"}); err != nil {
+		t.Fatal(err)
+	}
+	if msg := c.next(); msg.Error == nil || msg.Error.Code != codeServerNotInitialized {
+		t.Errorf("got response %+v, want a server not initialized error", msg)
+	}
+
+	var initResult initializeResult
+	c.call("initialize", map[string]any{}, &initResult)
+	id = json.RawMessage("2")
+	if err := c.conn.write(&message{ID: &id, Method: "textDocument/hover"}); err != nil {
+		t.Fatal(err)
+	}
+	if msg := c.next(); msg.Error == nil || msg.Error.Code != codeMethodNotFound {
+		t.Errorf("got response %+v, want a method not found error", msg)
+	}
+}
+
+func pathToURI(path string) string {
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,206 @@
+package lint
+
+import (
+	"errors"
+	"go/build"
+	"go/build/constraint"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// BuildConfig is type used for the build constraints configuration.
+// The files of a package not satisfying the build constraints (i.e. //go:build lines
+// and _GOOS_GOARCH file name suffixes) of the target, by default the system of the
+// go command without build tags, are not linted.
+type BuildConfig struct {
+	// Tags are the build tags satisfied, besides those of the target system and Go release.
+	Tags []string `toml:"tags"`
+	// GOOS are the target operating systems, defaults to the one of the go command.
+	GOOS []string `toml:"goos"`
+	// GOARCH are the target architectures, defaults to the one of the go command.
+	GOARCH []string `toml:"goarch"`
+	// Matrix makes each combination of GOOS and GOARCH be linted separately,
+	// the failures found for several combinations being reported once.
+	// It is required to set several GOOS or GOARCH.
+	Matrix bool `toml:"matrix"`
+}
+
+// buildTarget is a configuration of build constraints.
+type buildTarget struct {
+	goos, goarch string
+	tags         map[string]bool
+}
+
+// targets returns the build targets of the configuration, at least one.
+func (c BuildConfig) targets() ([]buildTarget, error) {
+	if !c.Matrix && (len(c.GOOS) > 1 || len(c.GOARCH) > 1) {
+		return nil, errors.New("several goos or goarch values require the build matrix to be enabled")
+	}
+
+	tags := map[string]bool{}
+	for _, tag := range c.Tags {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags[tag] = true
+		}
+	}
+	goos, goarch := c.GOOS, c.GOARCH
+	if len(goos) == 0 {
+		goos = []string{build.Default.GOOS}
+	}
+	if len(goarch) == 0 {
+		goarch = []string{build.Default.GOARCH}
+	}
+
+	var result []buildTarget
+	for _, targetOS := range goos {
+		for _, targetArch := range goarch {
+			result = append(result, buildTarget{goos: targetOS, goarch: targetArch, tags: tags})
+		}
+	}
+	return result, nil
+}
+
+// String returns the target as GOOS/GOARCH, followed by its tags if any.
+func (t *buildTarget) String() string {
+	result := t.goos + "/" + t.goarch
+	if tags := t.tagList(); len(tags) > 0 {
+		result += " (" + strings.Join(tags, ",") + ")"
+	}
+	return result
+}
+
+func (t *buildTarget) tagList() []string {
+	result := make([]string, 0, len(t.tags))
+	for tag := range t.tags {
+		result = append(result, tag)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// matchTag tells if the build tag is satisfied by the target.
+func (t *buildTarget) matchTag(tag string) bool {
+	switch {
+	case t.tags[tag]:
+		return true
+	case tag == t.goos || tag == t.goarch:
+		return true
+	case tag == "linux" && t.goos == "android",
+		tag == "solaris" && t.goos == "illumos",
+		tag == "darwin" && t.goos == "ios":
+		return true
+	case tag == "unix":
+		return unixOS[t.goos]
+	case tag == "gc":
+		return true
+	case tag == "cgo":
+		return build.Default.CgoEnabled && t.goos == build.Default.GOOS && t.goarch == build.Default.GOARCH
+	}
+	for _, release := range build.Default.ReleaseTags {
+		if tag == release {
+			return true
+		}
+	}
+	return false
+}
+
+// matches tells if the file of the given name and content satisfies the build constraints of the target.
+func (t *buildTarget) matches(filename string, content []byte) bool {
+	return t.matchesFileName(filepath.Base(filename)) && t.matchesBuildConstraint(filename, content)
+}
+
+// matchesFileName tells if the _GOOS, _GOARCH and _GOOS_GOARCH suffixes of the file name match the target.
+func (t *buildTarget) matchesFileName(name string) bool {
+	name, _, _ = strings.Cut(name, ".")
+	i := strings.Index(name, "_")
+	if i < 0 {
+		return true
+	}
+	parts := strings.Split(name[i:], "_")
+	if n := len(parts); n > 0 && parts[n-1] == "test" {
+		parts = parts[:n-1]
+	}
+	n := len(parts)
+	if n >= 2 && knownOS[parts[n-2]] && knownArch[parts[n-1]] {
+		return t.matchTag(parts[n-2]) && t.matchTag(parts[n-1])
+	}
+	if n >= 1 && (knownOS[parts[n-1]] || knownArch[parts[n-1]]) {
+		return t.matchTag(parts[n-1])
+	}
+	return true
+}
+
+// matchesBuildConstraint tells if the //go:build, or // +build, lines of the file are satisfied by the target.
+// Files that cannot be parsed are considered as satisfying them, for their errors to be reported.
+func (t *buildTarget) matchesBuildConstraint(filename string, content []byte) bool {
+	file, err := parser.ParseFile(token.NewFileSet(), filename, content, parser.PackageClauseOnly|parser.ParseComments)
+	if err != nil {
+		return true
+	}
+
+	var goBuild constraint.Expr
+	var plusBuild []constraint.Expr
+	for _, group := range file.Comments {
+		if group.Pos() >= file.Package {
+			break
+		}
+		for _, c := range group.List {
+			switch {
+			case constraint.IsGoBuild(c.Text):
+				if goBuild == nil {
+					goBuild, _ = constraint.Parse(c.Text)
+				}
+			case constraint.IsPlusBuild(c.Text):
+				if expr, err := constraint.Parse(c.Text); err == nil {
+					plusBuild = append(plusBuild, expr)
+				}
+			}
+		}
+	}
+
+	if goBuild != nil {
+		return goBuild.Eval(t.matchTag)
+	}
+	for _, expr := range plusBuild {
+		if !expr.Eval(t.matchTag) {
+			return false
+		}
+	}
+	return true
+}
+
+// filter returns the files satisfying the build constraints of the target.
+func (t *buildTarget) filter(filenames []string, contents map[string][]byte) []string {
+	var result []string
+	for _, filename := range filenames {
+		if t.matches(filename, contents[filename]) {
+			result = append(result, filename)
+		}
+	}
+	return result
+}
+
+// The known operating systems and architectures, as in go/build.
+var (
+	knownOS = map[string]bool{
+		"aix": true, "android": true, "darwin": true, "dragonfly": true, "freebsd": true,
+		"hurd": true, "illumos": true, "ios": true, "js": true, "linux": true, "nacl": true,
+		"netbsd": true, "openbsd": true, "plan9": true, "solaris": true, "wasip1": true,
+		"windows": true, "zos": true,
+	}
+	unixOS = map[string]bool{
+		"aix": true, "android": true, "darwin": true, "dragonfly": true, "freebsd": true,
+		"hurd": true, "illumos": true, "ios": true, "linux": true, "netbsd": true,
+		"openbsd": true, "solaris": true,
+	}
+	knownArch = map[string]bool{
+		"386": true, "amd64": true, "amd64p32": true, "arm": true, "armbe": true, "arm64": true,
+		"arm64be": true, "loong64": true, "mips": true, "mipsle": true, "mips64": true,
+		"mips64le": true, "mips64p32": true, "mips64p32le": true, "ppc": true, "ppc64": true,
+		"ppc64le": true, "riscv": true, "riscv64": true, "s390": true, "s390x": true,
+		"sparc": true, "sparc64": true, "wasm": true,
+	}
+)
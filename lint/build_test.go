@@ -0,0 +1,120 @@
+package lint_test
+
+import (
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/mgechev/revive/lint"
+)
+
+func TestLintBuildConstraints(t *testing.T) {
+	sources := map[string]string{
+		"p/a.go":              "package p\n",
+		"p/a_linux.go":        "package p\n\nfunc f() {}\n",
+		"p/a_windows.go":      "package p\n\nfunc f() {}\n",
+		"p/a_linux_arm64.go":  "package p\n",
+		"p/a_test.go":         "package p\n",
+		"p/a_windows_test.go": "package p\n",
+		"p/unix.go":           "//go:build unix\n\npackage p\n",
+		"p/integration.go":    "// Copyright\n\n//go:build integration && !windows\n\npackage p\n",
+		"p/legacy.go":         "// +build windows\n\npackage p\n",
+		"p/late.go":           "package p\n\n//go:build ignore\n",
+	}
+	var filenames []string
+	for name := range sources {
+		filenames = append(filenames, name)
+	}
+	sort.Strings(filenames)
+
+	lintFiles := func(t *testing.T, build lint.BuildConfig) []string {
+		t.Helper()
+		l := lint.New(func(path string) ([]byte, error) { return []byte(sources[path]), nil }, 0)
+		failures, err := l.Lint([][]string{filenames}, []lint.Rule{fileNameRule{}}, lint.Config{
+			Build:     build,
+			TypeCheck: lint.TypeCheckConfig{ReportErrors: true},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		typeErrors := false
+		for f := range failures {
+			if f.Category == "typecheck" {
+				typeErrors = true
+				continue
+			}
+			got = append(got, f.Failure)
+		}
+		if typeErrors {
+			// f is declared twice
+			got = append(got, "type errors")
+		}
+		sort.Strings(got)
+		return got
+	}
+
+	tests := []struct {
+		name  string
+		build lint.BuildConfig
+		want  []string
+	}{
+		{
+			name:  "linux",
+			build: lint.BuildConfig{GOOS: []string{"linux"}, GOARCH: []string{"amd64"}},
+			want:  []string{"p/a.go", "p/a_linux.go", "p/a_test.go", "p/late.go", "p/unix.go"},
+		},
+		{
+			name:  "linux arm64 with tags",
+			build: lint.BuildConfig{GOOS: []string{"linux"}, GOARCH: []string{"arm64"}, Tags: []string{"integration"}},
+			want:  []string{"p/a.go", "p/a_linux.go", "p/a_linux_arm64.go", "p/a_test.go", "p/integration.go", "p/late.go", "p/unix.go"},
+		},
+		{
+			name:  "android is linux",
+			build: lint.BuildConfig{GOOS: []string{"android"}, GOARCH: []string{"amd64"}},
+			want:  []string{"p/a.go", "p/a_linux.go", "p/a_test.go", "p/late.go", "p/unix.go"},
+		},
+		{
+			name:  "matrix",
+			build: lint.BuildConfig{GOOS: []string{"linux", "windows"}, GOARCH: []string{"amd64"}, Matrix: true},
+			// the files of both targets, each reported once
+			want: []string{"p/a.go", "p/a_linux.go", "p/a_test.go", "p/a_windows.go", "p/a_windows_test.go", "p/late.go", "p/legacy.go", "p/unix.go"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := append([]string{}, tt.want...)
+			sort.Strings(want)
+			if got := lintFiles(t, tt.build); !reflect.DeepEqual(got, want) {
+				t.Errorf("got failures\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+			}
+		})
+	}
+
+	t.Run("no build configuration", func(t *testing.T) {
+		// the target defaults to the system of the go command
+		want := lintFiles(t, lint.BuildConfig{GOOS: []string{runtime.GOOS}, GOARCH: []string{runtime.GOARCH}})
+		got := lintFiles(t, lint.BuildConfig{})
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got failures\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+		}
+		for _, failure := range got {
+			if failure == "type errors" {
+				t.Error("expected the files of other systems not to be type checked together")
+			}
+		}
+	})
+}
+
+func TestLintBuildConstraintsWithoutMatrix(t *testing.T) {
+	l := lint.New(func(string) ([]byte, error) { return []byte("package p\n"), nil }, 0)
+	_, err := l.Lint([][]string{{"p/a.go"}}, []lint.Rule{fileNameRule{}}, lint.Config{
+		Build: lint.BuildConfig{GOOS: []string{"linux", "windows"}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "require the build matrix") {
+		t.Fatalf("expected a build configuration error, got %v", err)
+	}
+}
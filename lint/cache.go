@@ -0,0 +1,80 @@
+package lint
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"go/build"
+	"runtime"
+
+	goversion "github.com/hashicorp/go-version"
+)
+
+// Cache stores the failures of the packages already linted.
+//
+// Entries are looked up by a key covering everything the failures of
+// a package depend on: the name and content of each file of the package,
+// the configuration of the rules, the Go version of the module,
+// the Go toolchain the results of the type checker depend on,
+// and the system of the go command, the default build target.
+// Implementations are expected to be safe for concurrent use,
+// and to treat Put as a best effort operation.
+type Cache interface {
+	Get(key string) ([]Failure, bool)
+	Put(key string, failures []Failure)
+}
+
+type cacheKeyFile struct {
+	Name string
+	Hash string
+}
+
+type cacheKeyRule struct {
+	Name   string
+	Config RuleConfig
+}
+
+// packageCacheKey returns the cache key of a package made of the given files.
+// The key covers the whole package because the failures of a file
+// may depend on the other files of its package (e.g. package rules, type checking.)
+func packageCacheKey(filenames []string, contents map[string][]byte, gover *goversion.Version, ruleSet []Rule, config Config) (string, error) {
+	key := struct {
+		GoVersion             string
+		Toolchain             string
+		Host                  string
+		IgnoreGeneratedHeader bool
+		EnableNolint          bool
+		Confidence            float64
+		Directives            DirectivesConfig
+		TypeCheck             TypeCheckConfig
+		Build                 BuildConfig
+		Rules                 []cacheKeyRule
+		Files                 []cacheKeyFile
+	}{
+		Toolchain:             runtime.Version(),
+		Host:                  build.Default.GOOS + "/" + build.Default.GOARCH,
+		IgnoreGeneratedHeader: config.IgnoreGeneratedHeader,
+		EnableNolint:          config.EnableNolint,
+		Confidence:            config.Confidence,
+		Directives:            config.Directives,
+		TypeCheck:             config.TypeCheck,
+		Build:                 config.Build,
+	}
+	if gover != nil {
+		key.GoVersion = gover.String()
+	}
+	for _, r := range ruleSet {
+		key.Rules = append(key.Rules, cacheKeyRule{Name: r.Name(), Config: config.Rules[r.Name()]})
+	}
+	for _, filename := range filenames {
+		hash := sha256.Sum256(contents[filename])
+		key.Files = append(key.Files, cacheKeyFile{Name: filename, Hash: hex.EncodeToString(hash[:])})
+	}
+
+	data, err := json.Marshal(key)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:]), nil
+}
@@ -1,6 +1,8 @@
 package lint
 
 import (
+	"fmt"
+
 	goversion "github.com/hashicorp/go-version"
 )
 
@@ -17,10 +19,26 @@ type RuleConfig struct {
 	Disabled  bool
 	// Exclude - rule-level file excludes, TOML related (strings)
 	Exclude []string
+	// Override - configurations of the rule for some files, the first matching one applies
+	Override []RuleOverride
 	// excludeFilters - regex-based file filters, initialized from Exclude
 	excludeFilters []*FileFilter
 }
 
+// RuleOverride is type used for the configuration of a rule for the files matching Paths.
+// Severity and Arguments, if set, replace those of the rule configuration.
+type RuleOverride struct {
+	// Paths - file filters selecting the files of the override
+	Paths     []string
+	Severity  Severity
+	Arguments Arguments
+	Disabled  bool
+	// pathFilters - regex-based file filters, initialized from Paths
+	pathFilters []*FileFilter
+	// rule - the rule configured with Arguments, if it is a ConfigurableRule
+	rule Rule
+}
+
 // Initialize - should be called after reading from TOML file
 func (rc *RuleConfig) Initialize() error {
 	for _, f := range rc.Exclude {
@@ -30,6 +48,61 @@ func (rc *RuleConfig) Initialize() error {
 		}
 		rc.excludeFilters = append(rc.excludeFilters, ff)
 	}
+
+	if rc.Override == nil {
+		return nil
+	}
+	overrides := make([]RuleOverride, len(rc.Override))
+	for i, o := range rc.Override {
+		if len(o.Paths) == 0 {
+			return fmt.Errorf("override #%d has no paths", i+1)
+		}
+		o.pathFilters = nil
+		for _, p := range o.Paths {
+			ff, err := ParseFileFilter(p)
+			if err != nil {
+				return err
+			}
+			o.pathFilters = append(o.pathFilters, ff)
+		}
+		overrides[i] = o
+	}
+	rc.Override = overrides
+	return nil
+}
+
+// ConfigureOverrides configures, for each override setting arguments,
+// the rule returned by newRule with these arguments, if it is a ConfigurableRule.
+// newRule must return a new instance of the rule each time it is called.
+func (rc *RuleConfig) ConfigureOverrides(newRule func() Rule) error {
+	overrides := make([]RuleOverride, len(rc.Override))
+	for i, o := range rc.Override {
+		o.rule = nil
+		if o.Arguments != nil {
+			r := newRule()
+			if cr, ok := r.(ConfigurableRule); ok {
+				if err := cr.Configure(o.Arguments); err != nil {
+					return fmt.Errorf("override #%d: %w", i+1, err)
+				}
+				o.rule = r
+			}
+		}
+		overrides[i] = o
+	}
+	rc.Override = overrides
+	return nil
+}
+
+// overrideFor returns the first override matching the file name, if any.
+func (rc *RuleConfig) overrideFor(name string) *RuleOverride {
+	for i := range rc.Override {
+		o := &rc.Override[i]
+		for _, ff := range o.pathFilters {
+			if ff.MatchFileName(name) {
+				return o
+			}
+		}
+	}
 	return nil
 }
 
@@ -54,18 +127,49 @@ type DirectiveConfig struct {
 // DirectivesConfig defines the config for all directives.
 type DirectivesConfig = map[string]DirectiveConfig
 
+// severityOf returns the severity set for the rule, or the directive, of the failure, if any.
+func (c *Config) severityOf(failure Failure) Severity {
+	if rc, ok := c.Rules[failure.RuleName]; ok && rc.Severity != "" {
+		return rc.Severity
+	}
+	if dc, ok := c.Directives[failure.RuleName]; ok && dc.Severity != "" {
+		return dc.Severity
+	}
+	return ""
+}
+
+// TypeCheckConfig is type used for the type checking configuration.
+type TypeCheckConfig struct {
+	// Importer selects how imported packages are resolved,
+	// either ImporterDefault (if empty) or ImporterModule.
+	Importer string `toml:"importer"`
+	// ReportErrors makes type checking errors be reported as failures.
+	ReportErrors bool `toml:"reportErrors"`
+}
+
 // Config defines the config of the linter.
 type Config struct {
 	IgnoreGeneratedHeader bool `toml:"ignoreGeneratedHeader"`
 	Confidence            float64
 	Severity              Severity
 	EnableAllRules        bool             `toml:"enableAllRules"`
+	EnableNolint          bool             `toml:"enableNolint"`
 	Rules                 RulesConfig      `toml:"rule"`
 	ErrorCode             int              `toml:"errorCode"`
 	WarningCode           int              `toml:"warningCode"`
 	Directives            DirectivesConfig `toml:"directive"`
 	Exclude               []string         `toml:"exclude"`
+	ExcludeIssues         []IssueExclusion `toml:"exclude-issue"`
+	TypeCheck             TypeCheckConfig  `toml:"typecheck"`
+	Build                 BuildConfig      `toml:"build"`
+	// Extends lists the configurations this one is merged on top of,
+	// either paths of configuration files or names of presets, e.g. "preset:recommended".
+	Extends []string `toml:"extends"`
 	// If set, overrides the go language version specified in go.mod of
 	// packages being linted, and assumes this specific language version.
 	GoVersion *goversion.Version
+	// RulesInfo are the descriptions of the linting rules implementing RuleMetadata, by their name
+	// in Rules and by their actual name, e.g. for the help text of the formatters.
+	// It is not read from the configuration file.
+	RulesInfo map[string]RuleInfo `toml:"-"`
 }
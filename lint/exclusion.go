@@ -0,0 +1,144 @@
+package lint
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync/atomic"
+)
+
+// IssueExclusion is type used for the configuration of the failures to exclude.
+// A failure is excluded if it matches all the criteria set.
+type IssueExclusion struct {
+	// Rule - the name of the rule of the failure
+	Rule string `toml:"rule"`
+	// Message - regular expression matching the message of the failure
+	Message string `toml:"message"`
+	// Path - file filter matching the file of the failure, see FileFilter
+	Path string `toml:"path"`
+	// Source - regular expression matching the source line of the failure
+	Source string `toml:"source"`
+
+	messageRE  *regexp.Regexp
+	pathFilter *FileFilter
+	sourceRE   *regexp.Regexp
+	// suppressed counts the failures excluded, shared by the copies of the exclusion
+	suppressed *atomic.Int64
+}
+
+// Initialize - should be called after reading from TOML file
+func (e *IssueExclusion) Initialize() error {
+	if e.Rule == "" && e.Message == "" && e.Path == "" && e.Source == "" {
+		return fmt.Errorf("no rule, message, path or source to match")
+	}
+
+	var err error
+	e.messageRE, e.pathFilter, e.sourceRE = nil, nil, nil
+	if e.Message != "" {
+		if e.messageRE, err = regexp.Compile(e.Message); err != nil {
+			return fmt.Errorf("invalid message regexp: %w", err)
+		}
+	}
+	if e.Path != "" {
+		if e.pathFilter, err = ParseFileFilter(e.Path); err != nil {
+			return err
+		}
+	}
+	if e.Source != "" {
+		if e.sourceRE, err = regexp.Compile(e.Source); err != nil {
+			return fmt.Errorf("invalid source regexp: %w", err)
+		}
+	}
+	if e.suppressed == nil {
+		// kept on later calls, so that the copies of the exclusion keep sharing it
+		e.suppressed = new(atomic.Int64)
+	}
+	return nil
+}
+
+// Suppressed returns the number of failures excluded by the exclusion.
+func (e *IssueExclusion) Suppressed() int64 {
+	if e.suppressed == nil {
+		return 0
+	}
+	return e.suppressed.Load()
+}
+
+// ResetSuppressed resets the number of failures excluded by the exclusion, e.g. before linting again.
+func (e *IssueExclusion) ResetSuppressed() {
+	if e.suppressed != nil {
+		e.suppressed.Store(0)
+	}
+}
+
+// String returns the criteria of the exclusion.
+func (e *IssueExclusion) String() string {
+	var criteria []string
+	for _, c := range []struct{ name, value string }{
+		{"rule", e.Rule},
+		{"message", e.Message},
+		{"path", e.Path},
+		{"source", e.Source},
+	} {
+		if c.value != "" {
+			criteria = append(criteria, fmt.Sprintf("%s %q", c.name, c.value))
+		}
+	}
+	return strings.Join(criteria, ", ")
+}
+
+// matches tells if the exclusion matches the failure, whose source line is given.
+func (e *IssueExclusion) matches(failure Failure, line []byte) bool {
+	if e.suppressed == nil {
+		return false // not initialized
+	}
+	switch {
+	case e.Rule != "" && e.Rule != failure.RuleName:
+		return false
+	case e.messageRE != nil && !e.messageRE.MatchString(failure.Failure):
+		return false
+	case e.pathFilter != nil && !e.pathFilter.MatchFileName(failure.GetFilename()):
+		return false
+	case e.sourceRE != nil && !e.sourceRE.Match(line):
+		return false
+	}
+	return true
+}
+
+// issueExcluder excludes the failures of a package matching the issue exclusions of the configuration.
+type issueExcluder struct {
+	exclusions []IssueExclusion
+	contents   map[string][]byte
+	lines      map[string][][]byte
+}
+
+func newIssueExcluder(exclusions []IssueExclusion, contents map[string][]byte) *issueExcluder {
+	return &issueExcluder{exclusions: exclusions, contents: contents, lines: map[string][][]byte{}}
+}
+
+// excludes tells if the failure must be excluded, counting it as suppressed by the first matching exclusion.
+func (x *issueExcluder) excludes(failure Failure) bool {
+	for i := range x.exclusions {
+		e := &x.exclusions[i]
+		if e.matches(failure, x.sourceLine(failure)) {
+			e.suppressed.Add(1)
+			return true
+		}
+	}
+	return false
+}
+
+func (x *issueExcluder) sourceLine(failure Failure) []byte {
+	filename := failure.GetFilename()
+	lines, ok := x.lines[filename]
+	if !ok {
+		lines = bytes.Split(x.contents[filename], []byte("\n"))
+		x.lines[filename] = lines
+	}
+	line := failure.Position.Start.Line
+	if line < 1 || line > len(lines) {
+		return nil
+	}
+	return bytes.TrimSuffix(lines[line-1], []byte("\r"))
+}
@@ -0,0 +1,73 @@
+package lint_test
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/mgechev/revive/lint"
+)
+
+func TestLintExcludeIssues(t *testing.T) {
+	sources := map[string]string{
+		"mocks/a.go": "package mocks\n\nvar mockA = 1\nvar b = 2\n",
+		"pkg/c.go":   "package pkg\n\nvar mockC = 1\nvar legacy = 2 // legacy\n",
+	}
+	exclusions := []lint.IssueExclusion{
+		{Rule: "var-decl", Message: "^var mock", Path: "mocks/**"},
+		{Source: `//\s*legacy$`},
+		{Rule: "other", Message: "."},
+	}
+	for i := range exclusions {
+		if err := exclusions[i].Initialize(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	config := lint.Config{Rules: lint.RulesConfig{"var-decl": {}}, ExcludeIssues: exclusions}
+	cache := &memoryCache{entries: map[string][]lint.Failure{}}
+
+	for _, run := range []string{"cold cache", "warm cache"} {
+		t.Run(run, func(t *testing.T) {
+			l := lint.New(func(path string) ([]byte, error) { return []byte(sources[path]), nil }, 0)
+			l.SetCache(cache)
+			failures, err := l.Lint([][]string{{"mocks/a.go"}, {"pkg/c.go"}}, []lint.Rule{varDeclRule{}}, config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for f := range failures {
+				got = append(got, f.GetFilename()+": "+f.Failure)
+			}
+			sort.Strings(got)
+			if want := []string{"mocks/a.go: var b", "pkg/c.go: var mockC"}; !reflect.DeepEqual(got, want) {
+				t.Errorf("got failures %v, want %v", got, want)
+			}
+		})
+	}
+
+	// each run suppressed one failure by each of the first two exclusions
+	for i, want := range []int64{2, 2, 0} {
+		if got := exclusions[i].Suppressed(); got != want {
+			t.Errorf("exclusion #%d (%s) suppressed %d failures, want %d", i+1, &exclusions[i], got, want)
+		}
+	}
+}
+
+func TestIssueExclusionInitialize(t *testing.T) {
+	tests := []struct {
+		exclusion lint.IssueExclusion
+		wantErr   bool
+	}{
+		{lint.IssueExclusion{Rule: "exported"}, false},
+		{lint.IssueExclusion{}, true},
+		{lint.IssueExclusion{Message: "("}, true},
+		{lint.IssueExclusion{Source: "["}, true},
+		{lint.IssueExclusion{Path: "a**b"}, true},
+	}
+
+	for _, tt := range tests {
+		if err := tt.exclusion.Initialize(); (err != nil) != tt.wantErr {
+			t.Errorf("Initialize() of %+v returned %v, want an error: %v", tt.exclusion, err, tt.wantErr)
+		}
+	}
+}
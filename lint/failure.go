@@ -12,6 +12,10 @@ const (
 	SeverityError = "error"
 )
 
+// FailureCategoryError is the category of the failures reporting an error
+// that prevented a file from being linted, e.g. the file cannot be read.
+const FailureCategoryError = "error"
+
 // Severity is the type for the failure types.
 type Severity string
 
@@ -21,6 +25,14 @@ type FailurePosition struct {
 	End   token.Position
 }
 
+// Edit describes a textual change suggested to fix a failure:
+// the bytes from Position.Start.Offset up to (but not including)
+// Position.End.Offset are replaced by NewText.
+type Edit struct {
+	Position FailurePosition
+	NewText  string
+}
+
 // Failure defines a struct for a linting failure.
 type Failure struct {
 	Failure    string
@@ -31,9 +43,22 @@ type Failure struct {
 	Confidence float64
 	// For future use
 	ReplacementLine string
+	// Edits is the suggested fix of the failure, if any.
+	// All edits must be applied together to fix the failure.
+	Edits []Edit `json:",omitempty"`
+	// Severity is the severity of the rule for the failure, set by the override
+	// of the rule matching its file or by the configuration of its package,
+	// if it differs from the one given to the linter.
+	// Formatters use the severity of the linter configuration if empty.
+	Severity Severity `json:",omitempty"`
 }
 
 // GetFilename returns the filename.
 func (f *Failure) GetFilename() string {
 	return f.Position.Start.Filename
 }
+
+// IsFixable returns true if the failure comes with a suggested fix.
+func (f *Failure) IsFixable() bool {
+	return len(f.Edits) > 0
+}
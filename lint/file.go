@@ -2,6 +2,8 @@ package lint
 
 import (
 	"bytes"
+	"context"
+	"fmt"
 	"go/ast"
 	"go/parser"
 	"go/printer"
@@ -10,6 +12,7 @@ import (
 	"math"
 	"regexp"
 	"strings"
+	"time"
 )
 
 // File abstraction used for representing files.
@@ -94,43 +97,149 @@ func (f *File) isMain() bool {
 	return f.AST.Name.Name == "main"
 }
 
-const directiveSpecifyDisableReason = "specify-disable-reason"
+const (
+	directiveSpecifyDisableReason = "specify-disable-reason"
+	directiveUnusedDisable        = "unused-disable"
+)
 
-func (f *File) lint(rules []Rule, config Config, failures chan Failure) {
+// lint applies the rules to the file and sends the resulting failures,
+// along with the given failures of package rules on this file, to the failures channel.
+func (f *File) lint(ctx context.Context, rules []Rule, config Config, packageFailures []Failure, failures chan Failure) {
 	rulesConfig := config.Rules
-	_, mustSpecifyDisableReason := config.Directives[directiveSpecifyDisableReason]
-	disabledIntervals := f.disabledIntervals(rules, mustSpecifyDisableReason, failures)
+	_, mustReportUnusedDisable := config.Directives[directiveUnusedDisable]
+	disabledIntervals, disableDirectives := f.disabledIntervals(ctx, rules, config, failures)
 	for _, currentRule := range rules {
+		if ctx.Err() != nil {
+			return // skip the remaining rules
+		}
+		if _, ok := currentRule.(PackageRule); ok {
+			continue // already applied at package level
+		}
 		ruleConfig := rulesConfig[currentRule.Name()]
 		if ruleConfig.MustExclude(f.Name) {
 			continue
 		}
-		currentFailures := currentRule.Apply(f, ruleConfig.Arguments)
+		r, arguments, severity := currentRule, ruleConfig.Arguments, Severity("")
+		if override := ruleConfig.overrideFor(f.Name); override != nil {
+			if override.Disabled {
+				continue
+			}
+			if override.rule != nil {
+				r = override.rule
+			}
+			if override.Arguments != nil {
+				arguments = override.Arguments
+			}
+			severity = override.Severity
+		}
+		start := time.Now()
+		currentFailures := r.Apply(f, arguments)
+		f.Pkg.stats.addRule(currentRule.Name(), time.Since(start), 1, len(currentFailures))
 		for idx, failure := range currentFailures {
 			if failure.RuleName == "" {
 				failure.RuleName = currentRule.Name()
 			}
+			if failure.Severity == "" {
+				failure.Severity = severity
+			}
 			if failure.Node != nil {
 				failure.Position = ToFailurePosition(failure.Node.Pos(), failure.Node.End(), f)
 			}
 			currentFailures[idx] = failure
 		}
-		currentFailures = f.filterFailures(currentFailures, disabledIntervals)
-		for _, failure := range currentFailures {
-			if failure.Confidence >= config.Confidence {
-				failures <- failure
+		f.report(ctx, currentFailures, disabledIntervals, config, failures)
+	}
+
+	var notExcluded []Failure
+	for _, failure := range packageFailures {
+		ruleConfig := rulesConfig[failure.RuleName]
+		if ruleConfig.MustExclude(f.Name) {
+			continue
+		}
+		if override := ruleConfig.overrideFor(f.Name); override != nil {
+			if override.Disabled {
+				continue
+			}
+			if failure.Severity == "" {
+				failure.Severity = override.Severity
 			}
 		}
+		notExcluded = append(notExcluded, failure)
+	}
+	f.report(ctx, notExcluded, disabledIntervals, config, failures)
+
+	if mustReportUnusedDisable && ctx.Err() == nil {
+		f.reportUnusedDisableDirectives(ctx, disableDirectives, failures)
+	}
+}
+
+// reportUnusedDisableDirectives sends a failure for each of the given
+// directives, or rule of a directive, that suppressed no failure.
+func (f *File) reportUnusedDisableDirectives(ctx context.Context, directives []*disableDirective, failures chan Failure) {
+	for _, d := range directives {
+		var messages []string
+		if d.ruleNames == nil {
+			if len(d.used) == 0 {
+				messages = append(messages, fmt.Sprintf("unused %s directive, it suppressed no failure", d.name))
+			}
+		} else {
+			for _, name := range d.ruleNames {
+				if !d.used[name] {
+					messages = append(messages, fmt.Sprintf("unused %s directive for the rule %q, it suppressed no failure", d.name, name))
+				}
+			}
+		}
+
+		for _, message := range messages {
+			if !sendFailure(ctx, failures, Failure{
+				Confidence: 1,
+				RuleName:   directiveUnusedDisable,
+				Failure:    message,
+				Position:   ToFailurePosition(d.comment.Pos(), d.comment.End(), f),
+				Node:       d.comment,
+			}) {
+				return
+			}
+		}
+	}
+}
+
+// report sends to the failures channel those of the given failures
+// that are not disabled and meet the confidence threshold.
+func (f *File) report(ctx context.Context, currentFailures []Failure, disabledIntervals disabledIntervalsMap, config Config, failures chan Failure) {
+	confident := []Failure{}
+	for _, failure := range currentFailures {
+		if failure.Confidence >= config.Confidence {
+			confident = append(confident, failure)
+		}
+	}
+	for _, failure := range f.filterFailures(confident, disabledIntervals) {
+		if !sendFailure(ctx, failures, failure) {
+			return
+		}
 	}
 }
 
 type enableDisableConfig struct {
 	enabled  bool
 	position int
+	// directive is the revive:disable directive of a disabling entry
+	directive *disableDirective
+}
+
+// disableDirective is a revive:disable directive of a file.
+type disableDirective struct {
+	comment *ast.Comment
+	// name is the name of the directive, e.g. "revive:disable-line"
+	name string
+	// ruleNames are the rules named by the directive, nil if it applies to all the rules
+	ruleNames []string
+	// used holds the names of the rules of which the directive suppressed a failure
+	used map[string]bool
 }
 
 const (
-	directiveRE  = `^//[\s]*revive:(enable|disable)(?:-(line|next-line))?(?::([^\s]+))?[\s]*(?: (.+))?$`
+	directiveRE  = `^//[\s]*revive:(enable|disable)(?:-(line|next-line|decl))?(?::([^\s]+))?[\s]*(?: (.+))?$`
 	directivePos = 1
 	modifierPos  = 2
 	rulesPos     = 3
@@ -139,8 +248,11 @@ const (
 
 var re = regexp.MustCompile(directiveRE)
 
-func (f *File) disabledIntervals(rules []Rule, mustSpecifyDisableReason bool, failures chan Failure) disabledIntervalsMap {
+func (f *File) disabledIntervals(ctx context.Context, rules []Rule, config Config, failures chan Failure) (disabledIntervalsMap, []*disableDirective) {
+	_, mustSpecifyDisableReason := config.Directives[directiveSpecifyDisableReason]
+	_, mustReportUnusedDisable := config.Directives[directiveUnusedDisable]
 	enabledDisabledRulesMap := make(map[string][]enableDisableConfig)
+	directives := []*disableDirective{}
 
 	getEnabledDisabledIntervals := func() disabledIntervalsMap {
 		result := make(disabledIntervalsMap)
@@ -149,7 +261,8 @@ func (f *File) disabledIntervals(rules []Rule, mustSpecifyDisableReason bool, fa
 			ruleResult := []DisabledInterval{}
 			for i := 0; i < len(disabledArr); i++ {
 				interval := DisabledInterval{
-					RuleName: ruleName,
+					RuleName:  ruleName,
+					directive: disabledArr[i].directive,
 					From: token.Position{
 						Filename: f.Name,
 						Line:     disabledArr[i].position,
@@ -171,7 +284,7 @@ func (f *File) disabledIntervals(rules []Rule, mustSpecifyDisableReason bool, fa
 		return result
 	}
 
-	handleConfig := func(isEnabled bool, line int, name string) {
+	handleConfig := func(isEnabled bool, line int, name string, directive *disableDirective) {
 		existing, ok := enabledDisabledRulesMap[name]
 		if !ok {
 			existing = []enableDisableConfig{}
@@ -181,32 +294,47 @@ func (f *File) disabledIntervals(rules []Rule, mustSpecifyDisableReason bool, fa
 			(len(existing) == 0 && isEnabled) {
 			return
 		}
-		existing = append(existing, enableDisableConfig{
+		entry := enableDisableConfig{
 			enabled:  isEnabled,
 			position: line,
-		})
+		}
+		if !isEnabled {
+			entry.directive = directive
+		}
+		existing = append(existing, entry)
 		enabledDisabledRulesMap[name] = existing
 	}
 
-	handleRules := func(filename, modifier string, isEnabled bool, line int, ruleNames []string) []DisabledInterval {
+	handleRules := func(filename, modifier string, isEnabled bool, line int, ruleNames []string, directive *disableDirective) []DisabledInterval {
 		var result []DisabledInterval
 		for _, name := range ruleNames {
 			if modifier == "line" {
-				handleConfig(isEnabled, line, name)
-				handleConfig(!isEnabled, line, name)
+				handleConfig(isEnabled, line, name, directive)
+				handleConfig(!isEnabled, line, name, directive)
 			} else if modifier == "next-line" {
-				handleConfig(isEnabled, line+1, name)
-				handleConfig(!isEnabled, line+1, name)
+				handleConfig(isEnabled, line+1, name, directive)
+				handleConfig(!isEnabled, line+1, name, directive)
 			} else {
-				handleConfig(isEnabled, line, name)
+				handleConfig(isEnabled, line, name, directive)
 			}
 		}
 		return result
 	}
 
-	handleComment := func(filename string, c *ast.CommentGroup, line int) {
-		comments := c.List
+	var declDocs map[*ast.CommentGroup]ast.Node
+	// nodeIntervals are the intervals of declarations and //nolint directives
+	nodeIntervals := []DisabledInterval{}
+
+	handleComment := func(filename string, group *ast.CommentGroup, line int) {
+		comments := group.List
 		for _, c := range comments {
+			if config.EnableNolint {
+				if intervals, ok := f.nolintIntervals(ctx, group, c, rules, mustSpecifyDisableReason, failures); ok {
+					nodeIntervals = append(nodeIntervals, intervals...)
+					continue
+				}
+			}
+
 			match := re.FindStringSubmatch(c.Text)
 			if len(match) == 0 {
 				continue
@@ -223,16 +351,55 @@ func (f *File) disabledIntervals(rules []Rule, mustSpecifyDisableReason bool, fa
 
 			mustCheckDisablingReason := mustSpecifyDisableReason && match[directivePos] == "disable"
 			if mustCheckDisablingReason && strings.Trim(match[reasonPos], " ") == "" {
-				failures <- Failure{
+				sendFailure(ctx, failures, Failure{
 					Confidence: 1,
 					RuleName:   directiveSpecifyDisableReason,
 					Failure:    "reason of lint disabling not found",
 					Position:   ToFailurePosition(c.Pos(), c.End(), f),
 					Node:       c,
-				}
+				})
 				continue // skip this linter disabling directive
 			}
 
+			var decl ast.Node
+			if match[modifierPos] == "decl" {
+				if match[directivePos] == "enable" {
+					continue // declarations can only be disabled
+				}
+				if declDocs == nil {
+					declDocs = f.declarationsByDoc()
+				}
+				var ok bool
+				if decl, ok = declDocs[group]; !ok {
+					if mustReportUnusedDisable {
+						sendFailure(ctx, failures, Failure{
+							Confidence: 1,
+							RuleName:   directiveUnusedDisable,
+							Failure:    "revive:disable-decl directive is not in the doc comment of a declaration",
+							Position:   ToFailurePosition(c.Pos(), c.End(), f),
+							Node:       c,
+						})
+					}
+					continue
+				}
+			}
+
+			var directive *disableDirective
+			if mustReportUnusedDisable {
+				knownRuleNames := f.checkDirectiveRuleNames(ctx, c, directiveName(match), ruleNames, rules, failures)
+				if len(ruleNames) > 0 && len(knownRuleNames) == 0 {
+					continue // the directive applies to no rule
+				}
+				ruleNames = knownRuleNames
+				if match[directivePos] == "disable" {
+					directive = &disableDirective{comment: c, name: directiveName(match), used: map[string]bool{}}
+					if len(ruleNames) > 0 {
+						directive.ruleNames = ruleNames
+					}
+					directives = append(directives, directive)
+				}
+			}
+
 			// TODO: optimize
 			if len(ruleNames) == 0 {
 				for _, rule := range rules {
@@ -240,7 +407,20 @@ func (f *File) disabledIntervals(rules []Rule, mustSpecifyDisableReason bool, fa
 				}
 			}
 
-			handleRules(filename, match[modifierPos], match[directivePos] == "enable", line, ruleNames)
+			if decl != nil {
+				for _, name := range ruleNames {
+					nodeIntervals = append(nodeIntervals, DisabledInterval{
+						RuleName:  name,
+						From:      f.ToPosition(decl.Pos()),
+						To:        f.ToPosition(decl.End()),
+						extent:    true,
+						directive: directive,
+					})
+				}
+				continue
+			}
+
+			handleRules(filename, match[modifierPos], match[directivePos] == "enable", line, ruleNames, directive)
 		}
 	}
 
@@ -249,25 +429,92 @@ func (f *File) disabledIntervals(rules []Rule, mustSpecifyDisableReason bool, fa
 		handleComment(f.Name, c, f.ToPosition(c.End()).Line)
 	}
 
-	return getEnabledDisabledIntervals()
+	result := getEnabledDisabledIntervals()
+	for _, interval := range nodeIntervals {
+		result[interval.RuleName] = append(result[interval.RuleName], interval)
+	}
+	return result, directives
+}
+
+// declarationsByDoc returns the declarations of the file,
+// including type and value specifications and fields, indexed by their doc comment.
+func (f *File) declarationsByDoc() map[*ast.CommentGroup]ast.Node {
+	result := map[*ast.CommentGroup]ast.Node{}
+	ast.Inspect(f.AST, func(n ast.Node) bool {
+		var doc *ast.CommentGroup
+		switch n := n.(type) {
+		case *ast.FuncDecl:
+			doc = n.Doc
+		case *ast.GenDecl:
+			doc = n.Doc
+		case *ast.TypeSpec:
+			doc = n.Doc
+		case *ast.ValueSpec:
+			doc = n.Doc
+		case *ast.Field:
+			doc = n.Doc
+		}
+		if doc != nil {
+			result[doc] = n
+		}
+		return true
+	})
+	return result
+}
+
+// directiveName returns the name of the directive matched by directiveRE, e.g. "revive:disable-line".
+func directiveName(match []string) string {
+	name := "revive:" + match[directivePos]
+	if match[modifierPos] != "" {
+		name += "-" + match[modifierPos]
+	}
+	return name
+}
+
+// checkDirectiveRuleNames sends a failure for each rule named by the directive
+// that is not one of the given rules, i.e. an unknown or disabled rule,
+// and returns the names of the other rules.
+func (f *File) checkDirectiveRuleNames(ctx context.Context, c *ast.Comment, directiveName string, ruleNames []string, rules []Rule, failures chan Failure) []string {
+	if len(ruleNames) == 0 {
+		return ruleNames
+	}
+
+	known := make(map[string]bool, len(rules))
+	for _, r := range rules {
+		known[r.Name()] = true
+	}
+
+	result := []string{}
+	for _, name := range ruleNames {
+		if known[name] {
+			result = append(result, name)
+			continue
+		}
+		sendFailure(ctx, failures, Failure{
+			Confidence: 1,
+			RuleName:   directiveUnusedDisable,
+			Failure:    fmt.Sprintf("%s directive names the unknown or disabled rule %q", directiveName, name),
+			Position:   ToFailurePosition(c.Pos(), c.End(), f),
+			Node:       c,
+		})
+	}
+	return result
 }
 
 func (File) filterFailures(failures []Failure, disabledIntervals disabledIntervalsMap) []Failure {
 	result := []Failure{}
 	for _, failure := range failures {
-		fStart := failure.Position.Start.Line
-		fEnd := failure.Position.End.Line
 		intervals, ok := disabledIntervals[failure.RuleName]
 		if !ok {
 			result = append(result, failure)
 		} else {
 			include := true
 			for _, interval := range intervals {
-				intStart := interval.From.Line
-				intEnd := interval.To.Line
-				if (fStart >= intStart && fStart <= intEnd) ||
-					(fEnd >= intStart && fEnd <= intEnd) {
+				if interval.disables(failure) {
 					include = false
+					if interval.directive != nil {
+						interval.directive.used[failure.RuleName] = true
+					}
 					break
 				}
 			}
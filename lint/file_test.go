@@ -0,0 +1,309 @@
+package lint_test
+
+import (
+	"fmt"
+	"go/ast"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/mgechev/revive/lint"
+)
+
+type funcDeclRule struct{}
+
+func (funcDeclRule) Name() string { return "func-decl" }
+
+func (funcDeclRule) Apply(file *lint.File, _ lint.Arguments) []lint.Failure {
+	var failures []lint.Failure
+	for _, decl := range file.AST.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok {
+			failures = append(failures, lint.Failure{Confidence: 1, Node: fn, Failure: "function " + fn.Name.Name})
+		}
+	}
+	return failures
+}
+
+func TestLintUnusedDisableDirectives(t *testing.T) {
+	const src = `package p
+
+//revive:disable-next-line:func-decl
+func a() {}
+
+//revive:disable-next-line:func-decl
+var v = 1
+
+//revive:disable:nosuchrule
+func b() {}
+
+//revive:disable
+func c() {}
+
+//revive:enable
+
+//revive:disable-next-line:func-decl,nosuchrule
+func d() {}
+
+//revive:disable
+//revive:enable
+`
+	tests := []struct {
+		name       string
+		directives lint.DirectivesConfig
+		want       []string
+	}{
+		{
+			name: "directive disabled",
+			want: []string{
+				"10: function b",
+			},
+		},
+		{
+			name:       "directive enabled",
+			directives: lint.DirectivesConfig{"unused-disable": {}},
+			want: []string{
+				"10: function b",
+				`17: revive:disable-next-line directive names the unknown or disabled rule "nosuchrule"`,
+				`20: unused revive:disable directive, it suppressed no failure`,
+				`6: unused revive:disable-next-line directive for the rule "func-decl", it suppressed no failure`,
+				`9: revive:disable directive names the unknown or disabled rule "nosuchrule"`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := lint.New(func(string) ([]byte, error) { return []byte(src), nil }, 0)
+			failures, err := l.Lint([][]string{{"p.go"}}, []lint.Rule{funcDeclRule{}}, lint.Config{
+				Rules:      lint.RulesConfig{},
+				Directives: tt.directives,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := []string{}
+			for f := range failures {
+				got = append(got, fmt.Sprintf("%d: %s", f.Position.Start.Line, f.Failure))
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got failures\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLintDisabledIntervalsWithinFailures(t *testing.T) {
+	const src = `package p
+
+func a() {
+	//revive:disable-line:func-decl
+}
+
+//revive:disable-decl:func-decl,file-name
+func b() {}
+
+func c() {}
+`
+	l := lint.New(func(string) ([]byte, error) { return []byte(src), nil }, 0)
+	failures, err := l.Lint([][]string{{"p.go"}}, []lint.Rule{funcDeclRule{}, fileNameRule{}}, lint.Config{
+		Rules: lint.RulesConfig{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := []string{}
+	for f := range failures {
+		got = append(got, fmt.Sprintf("%d: %s", f.Position.Start.Line, f.Failure))
+	}
+	sort.Strings(got)
+	// the failure of a() straddles the disabled line, the one of the file is not within b()
+	want := []string{"10: function c", "1: p.go"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got failures %q, want %q", got, want)
+	}
+}
+
+type varDeclRule struct{}
+
+func (varDeclRule) Name() string { return "var-decl" }
+
+func (varDeclRule) Apply(file *lint.File, _ lint.Arguments) []lint.Failure {
+	var failures []lint.Failure
+	ast.Inspect(file.AST, func(n ast.Node) bool {
+		if spec, ok := n.(*ast.ValueSpec); ok {
+			failures = append(failures, lint.Failure{Confidence: 1, Node: spec, Failure: "var " + spec.Names[0].Name})
+		}
+		return true
+	})
+	return failures
+}
+
+func TestLintNolint(t *testing.T) {
+	const src = `package p
+
+func a() { //nolint:func-decl
+}
+
+//nolint:revive // generated
+func b() {
+	var x = 1
+}
+
+// c does things.
+//
+//nolint:errcheck
+func c() {}
+
+func d() {
+	var y = 2 //nolint
+	var z = 3 //nolint:var-decl,func-decl
+}
+`
+	tests := []struct {
+		name         string
+		enableNolint bool
+		directives   lint.DirectivesConfig
+		want         []string
+	}{
+		{
+			name: "nolint disabled",
+			want: []string{"14: function c", "16: function d", "17: var y", "18: var z", "3: function a", "7: function b", "8: var x"},
+		},
+		{
+			name:         "nolint enabled",
+			enableNolint: true,
+			want:         []string{"14: function c", "16: function d"},
+		},
+		{
+			name:         "nolint enabled with reasons",
+			enableNolint: true,
+			directives:   lint.DirectivesConfig{"specify-disable-reason": {}},
+			want: []string{
+				"14: function c", "16: function d",
+				"17: reason of lint disabling not found", "17: var y",
+				"18: reason of lint disabling not found", "18: var z",
+				"3: function a", "3: reason of lint disabling not found",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := lint.New(func(string) ([]byte, error) { return []byte(src), nil }, 0)
+			failures, err := l.Lint([][]string{{"p.go"}}, []lint.Rule{funcDeclRule{}, varDeclRule{}}, lint.Config{
+				Rules:        lint.RulesConfig{},
+				Directives:   tt.directives,
+				EnableNolint: tt.enableNolint,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := []string{}
+			for f := range failures {
+				got = append(got, fmt.Sprintf("%d: %s", f.Position.Start.Line, f.Failure))
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got failures\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+// argumentsRule reports the arguments it is applied with.
+type argumentsRule struct{}
+
+func (argumentsRule) Name() string { return "arguments" }
+
+func (argumentsRule) Apply(file *lint.File, arguments lint.Arguments) []lint.Failure {
+	return []lint.Failure{{Confidence: 1, Node: file.AST, Failure: fmt.Sprint(arguments)}}
+}
+
+// limitRule reports the limit it is configured with.
+type limitRule struct{ limit int64 }
+
+func (*limitRule) Name() string { return "limit" }
+
+func (r *limitRule) Configure(arguments lint.Arguments) error {
+	r.limit = arguments[0].(int64)
+	return nil
+}
+
+func (r *limitRule) Apply(file *lint.File, _ lint.Arguments) []lint.Failure {
+	return []lint.Failure{{Confidence: 1, Node: file.AST, Failure: fmt.Sprint(r.limit)}}
+}
+
+func TestLintRuleOverrides(t *testing.T) {
+	overrides := []lint.RuleOverride{
+		{Paths: []string{"internal/core/**"}, Severity: lint.SeverityError},
+		{Paths: []string{"TEST"}, Arguments: lint.Arguments{int64(100)}},
+		{Paths: []string{"cmd/**"}, Severity: lint.SeverityWarning, Arguments: lint.Arguments{int64(50)}},
+		{Paths: []string{"gen/**"}, Disabled: true},
+	}
+	config := lint.Config{Rules: lint.RulesConfig{
+		"arguments": {Arguments: lint.Arguments{int64(10)}, Override: overrides},
+		"limit":     {Arguments: lint.Arguments{int64(10)}, Override: overrides},
+	}}
+	limit := &limitRule{}
+	newRules := map[string]func() lint.Rule{
+		"arguments": func() lint.Rule { return argumentsRule{} },
+		"limit":     func() lint.Rule { return &limitRule{} },
+	}
+	for name, rc := range config.Rules {
+		if err := rc.Initialize(); err != nil {
+			t.Fatal(err)
+		}
+		if err := rc.ConfigureOverrides(newRules[name]); err != nil {
+			t.Fatal(err)
+		}
+		config.Rules[name] = rc
+	}
+	if err := limit.Configure(config.Rules["limit"].Arguments); err != nil {
+		t.Fatal(err)
+	}
+
+	l := lint.New(func(string) ([]byte, error) { return []byte("package p\n"), nil }, 0)
+	failures, err := l.Lint([][]string{
+		{"internal/core/a.go", "internal/core/a_test.go"},
+		{"cmd/b.go", "cmd/b_test.go"},
+		{"gen/c.go"},
+		{"other/d.go"},
+	}, []lint.Rule{argumentsRule{}, limit}, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for f := range failures {
+		got = append(got, fmt.Sprintf("%s %s: %s %q", f.GetFilename(), f.RuleName, f.Failure, f.Severity))
+	}
+	sort.Strings(got)
+	want := []string{
+		// the first matching override wins
+		`cmd/b.go arguments: [50] "warning"`,
+		`cmd/b.go limit: 50 "warning"`,
+		`cmd/b_test.go arguments: [100] ""`,
+		`cmd/b_test.go limit: 100 ""`,
+		`internal/core/a.go arguments: [10] "error"`,
+		`internal/core/a.go limit: 10 "error"`,
+		`internal/core/a_test.go arguments: [10] "error"`,
+		`internal/core/a_test.go limit: 10 "error"`,
+		`other/d.go arguments: [10] ""`,
+		`other/d.go limit: 10 ""`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got failures\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestRuleConfigOverrideWithoutPaths(t *testing.T) {
+	rc := lint.RuleConfig{Override: []lint.RuleOverride{{Severity: lint.SeverityError}}}
+	if err := rc.Initialize(); err == nil || err.Error() != "override #1 has no paths" {
+		t.Errorf("got error %v, want a missing paths error", err)
+	}
+}
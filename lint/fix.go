@@ -0,0 +1,92 @@
+package lint
+
+import (
+	"bytes"
+	"sort"
+)
+
+// ApplyFixes applies the suggested fixes of the given failures to content.
+// The failures are expected to refer to the file whose source is content.
+//
+// Fixes are applied as a whole: a failure whose edits overlap with the edits
+// of an already accepted failure, or fall outside content, is skipped.
+// It returns the fixed content and the failures that were not fixed.
+func ApplyFixes(content []byte, failures []Failure) (fixed []byte, notFixed []Failure) {
+	candidates := make([]Failure, len(failures))
+	copy(candidates, failures)
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return firstEditOffset(candidates[i]) < firstEditOffset(candidates[j])
+	})
+
+	var accepted []Edit
+	for _, failure := range candidates {
+		if !failure.IsFixable() || !editsFit(failure.Edits, accepted, len(content)) {
+			notFixed = append(notFixed, failure)
+			continue
+		}
+		accepted = append(accepted, failure.Edits...)
+	}
+
+	sort.SliceStable(accepted, func(i, j int) bool {
+		return accepted[i].Position.Start.Offset < accepted[j].Position.Start.Offset
+	})
+
+	var buf bytes.Buffer
+	last := 0
+	for _, edit := range accepted {
+		buf.Write(content[last:edit.Position.Start.Offset])
+		buf.WriteString(edit.NewText)
+		last = edit.Position.End.Offset
+	}
+	buf.Write(content[last:])
+
+	return buf.Bytes(), notFixed
+}
+
+func firstEditOffset(failure Failure) int {
+	if !failure.IsFixable() {
+		return -1
+	}
+
+	result := failure.Edits[0].Position.Start.Offset
+	for _, edit := range failure.Edits[1:] {
+		if edit.Position.Start.Offset < result {
+			result = edit.Position.Start.Offset
+		}
+	}
+	return result
+}
+
+// editsFit returns true if the given edits are within the bounds of a content
+// of the given size and do not overlap with each other nor with the accepted ones.
+func editsFit(edits, accepted []Edit, size int) bool {
+	for i, edit := range edits {
+		start, end := edit.Position.Start.Offset, edit.Position.End.Offset
+		if start < 0 || start > end || end > size {
+			return false
+		}
+		for _, other := range accepted {
+			if editsOverlap(edit, other) {
+				return false
+			}
+		}
+		for _, other := range edits[:i] {
+			if editsOverlap(edit, other) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// editsOverlap returns true if the two edits touch the same bytes.
+// Two insertions at the same offset are considered overlapping because
+// the resulting order would be ambiguous.
+func editsOverlap(a, b Edit) bool {
+	aStart, aEnd := a.Position.Start.Offset, a.Position.End.Offset
+	bStart, bEnd := b.Position.Start.Offset, b.Position.End.Offset
+	if aStart == bStart {
+		return true
+	}
+	return aStart < bEnd && bStart < aEnd
+}
@@ -0,0 +1,64 @@
+package lint_test
+
+import (
+	"go/token"
+	"testing"
+
+	"github.com/mgechev/revive/lint"
+)
+
+func edit(start, end int, newText string) lint.Edit {
+	return lint.Edit{
+		Position: lint.FailurePosition{
+			Start: token.Position{Offset: start},
+			End:   token.Position{Offset: end},
+		},
+		NewText: newText,
+	}
+}
+
+func TestApplyFixes(t *testing.T) {
+	const content = "var x interface{} = nil\n"
+
+	t.Run("non overlapping", func(t *testing.T) {
+		failures := []lint.Failure{
+			{Failure: "drop nil", Edits: []lint.Edit{edit(17, 23, "")}},
+			{Failure: "use any", Edits: []lint.Edit{edit(6, 17, "any")}},
+		}
+		got, notFixed := lint.ApplyFixes([]byte(content), failures)
+		if string(got) != "var x any\n" {
+			t.Fatalf("got %q", got)
+		}
+		if len(notFixed) != 0 {
+			t.Fatalf("expected all failures to be fixed, got %v", notFixed)
+		}
+	})
+
+	t.Run("overlapping", func(t *testing.T) {
+		failures := []lint.Failure{
+			{Failure: "use any", Edits: []lint.Edit{edit(6, 17, "any")}},
+			{Failure: "rename", Edits: []lint.Edit{edit(4, 5, "y"), edit(15, 17, "{ }")}},
+		}
+		got, notFixed := lint.ApplyFixes([]byte(content), failures)
+		if string(got) != "var y interface{ } = nil\n" {
+			t.Fatalf("got %q", got)
+		}
+		if len(notFixed) != 1 || notFixed[0].Failure != "use any" {
+			t.Fatalf("expected 'use any' not to be fixed, got %v", notFixed)
+		}
+	})
+
+	t.Run("out of bounds and without fix", func(t *testing.T) {
+		failures := []lint.Failure{
+			{Failure: "out of bounds", Edits: []lint.Edit{edit(20, 30, "")}},
+			{Failure: "no fix"},
+		}
+		got, notFixed := lint.ApplyFixes([]byte(content), failures)
+		if string(got) != content {
+			t.Fatalf("got %q", got)
+		}
+		if len(notFixed) != 2 {
+			t.Fatalf("expected no failure to be fixed, got %v", notFixed)
+		}
+	})
+}
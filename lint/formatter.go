@@ -12,3 +12,11 @@ type Formatter interface {
 	Format(<-chan Failure, Config) (string, error)
 	Name() string
 }
+
+// StreamingFormatter is implemented by formatters whose output is a stream
+// of independent failures. Such formatters get the failures as soon as they
+// are found instead of sorted, unless a sort order is requested.
+type StreamingFormatter interface {
+	Formatter
+	Streaming() bool
+}
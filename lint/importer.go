@@ -0,0 +1,216 @@
+package lint
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/token"
+	"go/types"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/mod/modfile"
+)
+
+const (
+	// ImporterDefault resolves imports from the export data installed by the compiler.
+	ImporterDefault = "default"
+	// ImporterModule resolves imports through the go command,
+	// honoring go.mod, vendor directories and replace directives.
+	ImporterModule = "module"
+)
+
+// moduleImporter is a types.Importer resolving import paths with "go list"
+// from the directory of the package being type checked,
+// then reading the export data of the compiled packages.
+type moduleImporter struct {
+	ctx     context.Context
+	dir     string
+	paths   []string
+	fset    *token.FileSet
+	once    sync.Once
+	exports map[string]string // import path -> export data file
+	gc      types.Importer
+	// err is the error, if any, of the go command
+	err error
+	// target, if set, is the build target of the imports
+	target *buildTarget
+}
+
+func newModuleImporter(ctx context.Context, fset *token.FileSet, dir string, files map[string]*File, target *buildTarget) *moduleImporter {
+	seen := map[string]bool{}
+	var paths []string
+	for _, f := range files {
+		for _, path := range importPaths(f.AST) {
+			if path == "C" || path == "unsafe" || seen[path] {
+				continue
+			}
+			seen[path] = true
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	return &moduleImporter{ctx: ctx, dir: dir, paths: paths, fset: fset, target: target}
+}
+
+func importPaths(file *ast.File) []string {
+	var result []string
+	for _, imp := range file.Imports {
+		path, err := strconv.Unquote(imp.Path.Value)
+		if err == nil {
+			result = append(result, path)
+		}
+	}
+	return result
+}
+
+// Import implements types.Importer.
+// If the go command fails, it falls back to the default importer.
+func (i *moduleImporter) Import(path string) (*types.Package, error) {
+	i.once.Do(i.load)
+	if i.err != nil {
+		return importer.Default().Import(path)
+	}
+	return i.gc.Import(path)
+}
+
+func (i *moduleImporter) load() {
+	i.exports = map[string]string{}
+	i.gc = importer.ForCompiler(i.fset, "gc", i.lookup)
+	if len(i.paths) == 0 {
+		return
+	}
+
+	args := []string{"list", "-e", "-export", "-deps", "-json=ImportPath,Export"}
+	if i.target != nil {
+		args = append(args, "-tags="+strings.Join(i.target.tagList(), ","))
+	}
+	args = append(append(args, "--"), i.paths...)
+	cmd := exec.CommandContext(i.ctx, "go", args...)
+	cmd.Dir = i.dir
+	if i.target != nil {
+		cmd.Env = append(os.Environ(), "GOOS="+i.target.goos, "GOARCH="+i.target.goarch)
+	}
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			err = fmt.Errorf("%v: %s", err, exitErr.Stderr)
+		}
+		i.err = fmt.Errorf("resolving imports of %s: %w", i.dir, err)
+		return
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(out))
+	for dec.More() {
+		var pkg struct {
+			ImportPath string
+			Export     string
+		}
+		if err := dec.Decode(&pkg); err != nil {
+			i.err = fmt.Errorf("resolving imports of %s: %w", i.dir, err)
+			return
+		}
+		if pkg.Export != "" {
+			i.exports[pkg.ImportPath] = pkg.Export
+		}
+	}
+}
+
+func (i *moduleImporter) lookup(path string) (io.ReadCloser, error) {
+	export, ok := i.exports[path]
+	if !ok {
+		return nil, fmt.Errorf("no export data for %q", path)
+	}
+	return os.Open(export)
+}
+
+// testImporter resolves the imports of an external test package (e.g. foo_test),
+// resolving the package under test (foo) to its type checked package,
+// which includes the declarations of its _test.go files.
+type testImporter struct {
+	types.Importer
+	underTest *Package
+	dir       string
+	once      sync.Once
+	// path is the import path of the package under test, if known
+	path string
+}
+
+func newTestImporter(imp types.Importer, underTest *Package, dir string) *testImporter {
+	if imp == nil {
+		imp = importer.Default()
+	}
+	return &testImporter{Importer: imp, underTest: underTest, dir: dir}
+}
+
+// Import implements types.Importer.
+func (i *testImporter) Import(path string) (*types.Package, error) {
+	if i.isUnderTest(path) {
+		i.underTest.TypeCheck()
+		if pkg := i.underTest.TypesPkg(); pkg != nil {
+			return pkg, nil
+		}
+	}
+	return i.Importer.Import(path)
+}
+
+// isUnderTest tells if the import path is the one of the package under test.
+// Without go.mod file, the package under test is the one imported by the name of its directory,
+// e.g. "foo" for the directory foo, unless it is a package of the standard library.
+func (i *testImporter) isUnderTest(importPath string) bool {
+	i.once.Do(func() { i.path = importPathOf(i.dir) })
+	if i.path != "" {
+		return importPath == i.path
+	}
+	if strings.ContainsAny(importPath, "./") || isStandardPackage(importPath) {
+		return false
+	}
+	dir, err := filepath.Abs(i.dir)
+	return err == nil && importPath == filepath.Base(dir)
+}
+
+// isStandardPackage tells if the import path is the one of a package of the standard library.
+func isStandardPackage(importPath string) bool {
+	info, err := os.Stat(filepath.Join(build.Default.GOROOT, "src", filepath.FromSlash(importPath)))
+	return err == nil && info.IsDir()
+}
+
+// importPathOf returns the import path of the package in dir, according to the go.mod file of its module,
+// or an empty string if unknown.
+func importPathOf(dir string) string {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	modFileName, err := retrieveModFile(dir)
+	if err != nil {
+		return ""
+	}
+	mod, err := os.ReadFile(modFileName)
+	if err != nil {
+		return ""
+	}
+	modPath := modfile.ModulePath(mod)
+	if modPath == "" {
+		return ""
+	}
+	rel, err := filepath.Rel(filepath.Dir(modFileName), dir)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return ""
+	}
+	return path.Join(modPath, filepath.ToSlash(rel))
+}
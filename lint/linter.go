@@ -3,8 +3,10 @@ package lint
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"fmt"
 	"go/token"
+	"go/types"
 	"os"
 	"path"
 	"path/filepath"
@@ -12,6 +14,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	goversion "github.com/hashicorp/go-version"
 	"golang.org/x/mod/modfile"
@@ -26,8 +29,15 @@ type disabledIntervalsMap = map[string][]DisabledInterval
 type Linter struct {
 	reader         ReadFile
 	fileReadTokens chan struct{}
+	cache          Cache
+	configResolver ConfigResolver
+	stats          *Stats
 }
 
+// ConfigResolver returns the rules and the configuration to lint
+// the package in the given directory with.
+type ConfigResolver func(dir string) ([]Rule, Config, error)
+
 // New creates a new Linter
 func New(reader ReadFile, maxOpenFiles int) Linter {
 	var fileReadTokens chan struct{}
@@ -40,11 +50,36 @@ func New(reader ReadFile, maxOpenFiles int) Linter {
 	}
 }
 
-func (l Linter) readFile(path string) (result []byte, err error) {
+// SetCache sets the cache used to replay the failures of the packages
+// whose files and configuration did not change since they were linted.
+// A nil cache disables caching.
+func (l *Linter) SetCache(cache Cache) {
+	l.cache = cache
+}
+
+// SetStats makes the linter collect its timing statistics into stats.
+// Nil statistics, the default, disable their collection.
+func (l *Linter) SetStats(stats *Stats) {
+	l.stats = stats
+}
+
+// SetConfigResolver makes the linter lint each package with the rules and the configuration
+// returned by the resolver, instead of those given to Lint.
+// Failures are then reported with the severity of their package configuration.
+// A nil resolver lints all the packages with the rules and configuration given to Lint.
+func (l *Linter) SetConfigResolver(resolver ConfigResolver) {
+	l.configResolver = resolver
+}
+
+func (l Linter) readFile(ctx context.Context, path string) (result []byte, err error) {
 	if l.fileReadTokens != nil {
 		// "take" a token by writing to the channel.
 		// It will block if no more space in the channel's buffer
-		l.fileReadTokens <- struct{}{}
+		select {
+		case l.fileReadTokens <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
 		defer func() {
 			// "free" a token by reading from the channel
 			<-l.fileReadTokens
@@ -62,14 +97,36 @@ var (
 
 // Lint lints a set of files with the specified rule.
 func (l *Linter) Lint(packages [][]string, ruleSet []Rule, config Config) (<-chan Failure, error) {
+	return l.LintContext(context.Background(), packages, ruleSet, config)
+}
+
+// LintContext lints a set of files with the specified rule.
+//
+// When ctx is done, the linter stops reading files, skips the remaining rules
+// and closes the failures channel as soon as the running rules return,
+// even if the channel is no longer read.
+func (l *Linter) LintContext(ctx context.Context, packages [][]string, ruleSet []Rule, config Config) (<-chan Failure, error) {
 	failures := make(chan Failure)
 
 	perModVersions := make(map[string]*goversion.Version)
 	perPkgVersions := make([]*goversion.Version, len(packages))
+	perPkgRuleSets := make([][]Rule, len(packages))
+	perPkgConfigs := make([]Config, len(packages))
 	for n, files := range packages {
 		if len(files) == 0 {
 			continue
 		}
+		perPkgRuleSets[n], perPkgConfigs[n] = ruleSet, config
+		if l.configResolver != nil {
+			var err error
+			perPkgRuleSets[n], perPkgConfigs[n], err = l.configResolver(filepath.Dir(files[0]))
+			if err != nil {
+				return nil, err
+			}
+		}
+		if _, err := perPkgConfigs[n].Build.targets(); err != nil {
+			return nil, fmt.Errorf("build configuration: %w", err)
+		}
 		if config.GoVersion != nil {
 			perPkgVersions[n] = config.GoVersion
 			continue
@@ -105,13 +162,25 @@ func (l *Linter) Lint(packages [][]string, ruleSet []Rule, config Config) (<-cha
 	var wg sync.WaitGroup
 	for n := range packages {
 		wg.Add(1)
-		go func(pkg []string, gover *goversion.Version) {
-			if err := l.lintPackage(pkg, gover, ruleSet, config, failures); err != nil {
-				fmt.Fprintln(os.Stderr, err)
-				os.Exit(1)
-			}
+		go func(pkg []string, gover *goversion.Version, ruleSet []Rule, config Config) {
 			defer wg.Done()
-		}(packages[n], perPkgVersions[n])
+			if l.configResolver == nil {
+				l.lintPackage(ctx, pkg, gover, ruleSet, config, failures)
+				return
+			}
+
+			pkgFailures := make(chan Failure)
+			go func() {
+				defer close(pkgFailures)
+				l.lintPackage(ctx, pkg, gover, ruleSet, config, pkgFailures)
+			}()
+			for failure := range pkgFailures {
+				if failure.Severity == "" && failure.Category != FailureCategoryError {
+					failure.Severity = config.severityOf(failure)
+				}
+				sendFailure(ctx, failures, failure)
+			}
+		}(packages[n], perPkgVersions[n], perPkgRuleSets[n], perPkgConfigs[n])
 	}
 
 	go func() {
@@ -122,40 +191,208 @@ func (l *Linter) Lint(packages [][]string, ruleSet []Rule, config Config) (<-cha
 	return failures, nil
 }
 
-func (l *Linter) lintPackage(filenames []string, gover *goversion.Version, ruleSet []Rule, config Config, failures chan Failure) error {
+// lintPackage lints the given files as a single package.
+// Files that cannot be read are reported as failures of category FailureCategoryError
+// and the remaining files of the package are still linted.
+func (l *Linter) lintPackage(ctx context.Context, filenames []string, gover *goversion.Version, ruleSet []Rule, config Config, failures chan Failure) {
 	if len(filenames) == 0 {
-		return nil
+		return
 	}
 
-	pkg := &Package{
-		fset:      token.NewFileSet(),
-		files:     map[string]*File{},
-		goVersion: gover,
-	}
+	// packages type checked with the module importer depend on the sources of
+	// their imports, that are not covered by the cache key
+	cacheable := l.cache != nil && config.TypeCheck.Importer != ImporterModule
+
+	contents := make(map[string][]byte, len(filenames))
+	readable := make([]string, 0, len(filenames))
 	for _, filename := range filenames {
-		content, err := l.readFile(filename)
+		if ctx.Err() != nil {
+			return
+		}
+		content, err := l.readFile(ctx, filename)
 		if err != nil {
-			return err
+			addReadErrorFailure(ctx, filename, err, failures)
+			cacheable = false
+			continue
+		}
+		contents[filename] = content
+		readable = append(readable, filename)
+	}
+
+	if len(config.ExcludeIssues) > 0 {
+		var wait func()
+		failures, wait = excludeIssues(ctx, config.ExcludeIssues, contents, failures)
+		defer wait()
+	}
+
+	if !cacheable {
+		l.lintContents(ctx, readable, contents, gover, ruleSet, config, failures)
+		return
+	}
+
+	key, err := packageCacheKey(readable, contents, gover, ruleSet, config)
+	if err != nil {
+		l.lintContents(ctx, readable, contents, gover, ruleSet, config, failures)
+		return
+	}
+
+	if cached, ok := l.cache.Get(key); ok {
+		for _, failure := range cached {
+			if !sendFailure(ctx, failures, failure) {
+				return
+			}
+		}
+		return
+	}
+
+	var collected []Failure
+	pkgFailures := make(chan Failure)
+	go func() {
+		defer close(pkgFailures)
+		l.lintContents(ctx, readable, contents, gover, ruleSet, config, pkgFailures)
+	}()
+	for failure := range pkgFailures {
+		collected = append(collected, failure)
+		sendFailure(ctx, failures, failure)
+	}
+
+	if ctx.Err() == nil {
+		l.cache.Put(key, collected)
+	}
+}
+
+// excludeIssues returns a channel forwarding to failures those not matching the given exclusions,
+// and a function to call once all the failures are sent, waiting for their forwarding to complete.
+// Exclusions apply to the failures of the cache too, so that they count the failures they suppress.
+func excludeIssues(ctx context.Context, exclusions []IssueExclusion, contents map[string][]byte, failures chan Failure) (chan Failure, func()) {
+	excluder := newIssueExcluder(exclusions, contents)
+	filtered := make(chan Failure)
+	forwarded := make(chan struct{})
+	go func() {
+		defer close(forwarded)
+		for failure := range filtered {
+			if !excluder.excludes(failure) {
+				sendFailure(ctx, failures, failure)
+			}
+		}
+	}()
+	return filtered, func() {
+		close(filtered)
+		<-forwarded
+	}
+}
+
+// lintContents lints the given files, whose content is already read, as a single package.
+// Only the files satisfying the build constraints are linted, once for each build target.
+func (l *Linter) lintContents(ctx context.Context, filenames []string, contents map[string][]byte, gover *goversion.Version, ruleSet []Rule, config Config, failures chan Failure) {
+	targets, _ := config.Build.targets() // already checked by LintContext
+	if len(targets) == 1 {
+		l.lintFiles(ctx, targets[0].filter(filenames, contents), contents, gover, ruleSet, config, &targets[0], failures)
+		return
+	}
+
+	type failureKey struct {
+		ruleName, category, failure string
+		start, end                  token.Position
+	}
+	linted := map[string]bool{}
+	reported := map[failureKey]bool{}
+	for i := range targets {
+		target := &targets[i]
+		selected := target.filter(filenames, contents)
+		fileSet := strings.Join(selected, "\x00")
+		if linted[fileSet] {
+			continue // same files, same failures
+		}
+		linted[fileSet] = true
+
+		targetFailures := make(chan Failure)
+		go func() {
+			defer close(targetFailures)
+			l.lintFiles(ctx, selected, contents, gover, ruleSet, config, target, targetFailures)
+		}()
+		for failure := range targetFailures {
+			key := failureKey{failure.RuleName, failure.Category, failure.Failure, failure.Position.Start, failure.Position.End}
+			if reported[key] {
+				continue
+			}
+			reported[key] = true
+			sendFailure(ctx, failures, failure)
 		}
+	}
+}
+
+// lintFiles lints the given files of a directory, for the given build target if not nil.
+// Files are grouped by package clause, so that an external test package (e.g. foo_test)
+// is linted apart from the package under test (foo), against which it is type checked.
+func (l *Linter) lintFiles(ctx context.Context, filenames []string, contents map[string][]byte, gover *goversion.Version, ruleSet []Rule, config Config, target *buildTarget, failures chan Failure) {
+	if len(filenames) == 0 {
+		return
+	}
+	fset := token.NewFileSet()
+	newPackage := func() *Package {
+		pkg := &Package{
+			fset:      fset,
+			files:     map[string]*File{},
+			goVersion: gover,
+			stats:     l.stats,
+		}
+		if target != nil {
+			pkg.sizes = types.SizesFor("gc", target.goarch)
+		}
+		return pkg
+	}
+
+	parsing := newPackage()
+	packages := map[string]*Package{}
+	var names []string
+	parseTimes := map[string]time.Duration{}
+	for _, filename := range filenames {
+		content := contents[filename]
 		if !config.IgnoreGeneratedHeader && isGenerated(content) {
 			continue
 		}
 
-		file, err := NewFile(filename, content, pkg)
+		start := time.Now()
+		file, err := NewFile(filename, content, parsing)
 		if err != nil {
-			addInvalidFileFailure(filename, err.Error(), failures)
+			addInvalidFileFailure(ctx, filename, err.Error(), failures)
 			continue
 		}
+		name := file.AST.Name.Name
+		pkg, ok := packages[name]
+		if !ok {
+			pkg = newPackage()
+			packages[name] = pkg
+			names = append(names, name)
+		}
+		file.Pkg = pkg
 		pkg.files[filename] = file
+		parseTimes[name] += time.Since(start)
 	}
 
-	if len(pkg.files) == 0 {
-		return nil
+	if len(packages) == 0 || ctx.Err() != nil {
+		return
 	}
 
-	pkg.lint(ruleSet, config, failures)
+	dir := filepath.Dir(filenames[0])
+	for _, name := range names {
+		pkg := packages[name]
+		l.stats.addParse(pkg, parseTimes[name])
+		if config.TypeCheck.Importer == ImporterModule {
+			pkg.importer = newModuleImporter(ctx, fset, dir, pkg.files, target)
+		}
+		if underTest, ok := packages[strings.TrimSuffix(name, "_test")]; ok && underTest != pkg {
+			pkg.importer = newTestImporter(pkg.importer, underTest, dir)
+		}
+	}
 
-	return nil
+	for _, name := range names {
+		if ctx.Err() != nil {
+			return
+		}
+		packages[name].lint(ctx, ruleSet, config, failures)
+	}
 }
 
 func detectGoMod(dir string) (rootDir string, ver *goversion.Version, err error) {
@@ -212,13 +449,37 @@ func isGenerated(src []byte) bool {
 }
 
 // addInvalidFileFailure adds a failure for an invalid formatted file
-func addInvalidFileFailure(filename, errStr string, failures chan Failure) {
+func addInvalidFileFailure(ctx context.Context, filename, errStr string, failures chan Failure) {
 	position := getPositionInvalidFile(filename, errStr)
-	failures <- Failure{
+	sendFailure(ctx, failures, Failure{
 		Confidence: 1,
 		Failure:    fmt.Sprintf("invalid file %s: %v", filename, errStr),
 		Category:   "validity",
 		Position:   position,
+	})
+}
+
+// addReadErrorFailure adds a failure for a file that cannot be read
+func addReadErrorFailure(ctx context.Context, filename string, err error, failures chan Failure) {
+	sendFailure(ctx, failures, Failure{
+		Confidence: 1,
+		Failure:    fmt.Sprintf("cannot read file %s: %v", filename, err),
+		Category:   FailureCategoryError,
+		Position: FailurePosition{
+			Start: token.Position{Filename: filename},
+			End:   token.Position{Filename: filename},
+		},
+	})
+}
+
+// sendFailure sends the failure to the failures channel unless ctx is done first.
+// It returns false if the failure was not sent.
+func sendFailure(ctx context.Context, failures chan Failure, failure Failure) bool {
+	select {
+	case failures <- failure:
+		return true
+	case <-ctx.Done():
+		return false
 	}
 }
 
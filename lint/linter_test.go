@@ -0,0 +1,275 @@
+package lint_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mgechev/revive/lint"
+)
+
+type fileNameRule struct{}
+
+func (fileNameRule) Name() string { return "file-name" }
+
+func (fileNameRule) Apply(file *lint.File, _ lint.Arguments) []lint.Failure {
+	return []lint.Failure{{Confidence: 1, Node: file.AST, Failure: file.Name}}
+}
+
+func TestLintReadError(t *testing.T) {
+	sources := map[string]string{
+		"a/ok.go":    "package a\n",
+		"b/other.go": "package b\n",
+	}
+	l := lint.New(func(path string) ([]byte, error) {
+		src, ok := sources[path]
+		if !ok {
+			return nil, errors.New("permission denied")
+		}
+		return []byte(src), nil
+	}, 1)
+
+	failures, err := l.Lint([][]string{{"a/ok.go", "a/unreadable.go"}, {"b/other.go"}}, []lint.Rule{fileNameRule{}}, lint.Config{
+		Rules: map[string]lint.RuleConfig{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := map[string]lint.Failure{}
+	for f := range failures {
+		got[f.Failure] = f
+	}
+
+	for _, name := range []string{"a/ok.go", "b/other.go"} {
+		if _, ok := got[name]; !ok {
+			t.Errorf("expected %s to be linted, got %v", name, got)
+		}
+	}
+
+	readErr, ok := got["cannot read file a/unreadable.go: permission denied"]
+	if !ok {
+		t.Fatalf("expected a failure for the unreadable file, got %v", got)
+	}
+	if readErr.Category != lint.FailureCategoryError {
+		t.Errorf("expected category %q, got %q", lint.FailureCategoryError, readErr.Category)
+	}
+	if readErr.GetFilename() != "a/unreadable.go" {
+		t.Errorf("expected filename a/unreadable.go, got %q", readErr.GetFilename())
+	}
+	if len(got) != 3 {
+		t.Errorf("expected 3 failures, got %d: %v", len(got), got)
+	}
+}
+
+func lintManyPackages(ctx context.Context, t *testing.T) <-chan lint.Failure {
+	t.Helper()
+
+	var packages [][]string
+	for i := 0; i < 20; i++ {
+		packages = append(packages, []string{fmt.Sprintf("p%d/a.go", i), fmt.Sprintf("p%d/b.go", i)})
+	}
+	l := lint.New(func(string) ([]byte, error) {
+		return []byte("package p\n"), nil
+	}, 2)
+
+	failures, err := l.LintContext(ctx, packages, []lint.Rule{fileNameRule{}}, lint.Config{
+		Rules: map[string]lint.RuleConfig{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return failures
+}
+
+// waitForGoroutines waits for the number of goroutines to go back to at most want.
+func waitForGoroutines(t *testing.T, want int) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for runtime.NumGoroutine() > want {
+		if time.Now().After(deadline) {
+			buf := make([]byte, 1<<16)
+			t.Fatalf("leaked goroutines: want at most %d, got %d\n%s", want, runtime.NumGoroutine(), buf[:runtime.Stack(buf, true)])
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestLintContextCancelWhileNotReading(t *testing.T) {
+	before := runtime.NumGoroutine()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	failures := lintManyPackages(ctx, t)
+	<-failures // the linting is running, then the consumer stops reading
+	cancel()
+
+	waitForGoroutines(t, before)
+	select {
+	case _, ok := <-failures:
+		if ok {
+			t.Fatal("expected the failures channel to be closed")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the failures channel was not closed")
+	}
+}
+
+func TestLintContextAlreadyCancelled(t *testing.T) {
+	before := runtime.NumGoroutine()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	count := 0
+	for range lintManyPackages(ctx, t) {
+		count++
+	}
+	if count != 0 {
+		t.Errorf("expected no failure, got %d", count)
+	}
+	waitForGoroutines(t, before)
+}
+
+func TestLintContextCompletes(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	count := 0
+	for range lintManyPackages(context.Background(), t) {
+		count++
+	}
+	if count != 40 {
+		t.Errorf("expected 40 failures, got %d", count)
+	}
+	waitForGoroutines(t, before)
+}
+
+type memoryCache struct {
+	sync.Mutex
+	entries map[string][]lint.Failure
+}
+
+func (c *memoryCache) Get(key string) ([]lint.Failure, bool) {
+	c.Lock()
+	defer c.Unlock()
+	failures, ok := c.entries[key]
+	return failures, ok
+}
+
+func (c *memoryCache) Put(key string, failures []lint.Failure) {
+	c.Lock()
+	defer c.Unlock()
+	c.entries[key] = failures
+}
+
+type countingRule struct {
+	applied atomic.Int32
+}
+
+func (*countingRule) Name() string { return "counting" }
+
+func (r *countingRule) Apply(file *lint.File, _ lint.Arguments) []lint.Failure {
+	r.applied.Add(1)
+	return []lint.Failure{{Confidence: 1, Node: file.AST, Failure: file.Name}}
+}
+
+func TestLintCache(t *testing.T) {
+	sources := map[string]string{
+		"a/a.go": "package a\n",
+		"a/b.go": "package a\n",
+		"b/c.go": "package b\n",
+	}
+	cache := &memoryCache{entries: map[string][]lint.Failure{}}
+	r := &countingRule{}
+	config := lint.Config{Rules: map[string]lint.RuleConfig{}}
+
+	lintAll := func() []string {
+		t.Helper()
+		l := lint.New(func(path string) ([]byte, error) {
+			return []byte(sources[path]), nil
+		}, 0)
+		l.SetCache(cache)
+		failures, err := l.Lint([][]string{{"a/a.go", "a/b.go"}, {"b/c.go"}}, []lint.Rule{r}, config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var result []string
+		for f := range failures {
+			result = append(result, f.Failure+"@"+f.GetFilename())
+		}
+		sort.Strings(result)
+		return result
+	}
+
+	want := []string{"a/a.go@a/a.go", "a/b.go@a/b.go", "b/c.go@b/c.go"}
+	for _, tc := range []struct {
+		name        string
+		change      func()
+		wantApplied int32
+	}{
+		{name: "cold cache", change: func() {}, wantApplied: 3},
+		{name: "unchanged", change: func() {}, wantApplied: 0},
+		{name: "file changed", change: func() { sources["a/b.go"] = "package a\n\n" }, wantApplied: 2},
+		{name: "rule configuration changed", change: func() {
+			config.Rules["counting"] = lint.RuleConfig{Arguments: lint.Arguments{"x"}}
+		}, wantApplied: 3},
+		{name: "unchanged again", change: func() {}, wantApplied: 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.change()
+			r.applied.Store(0)
+			if got := lintAll(); !reflect.DeepEqual(got, want) {
+				t.Errorf("got failures %v, want %v", got, want)
+			}
+			if got := r.applied.Load(); got != tc.wantApplied {
+				t.Errorf("rule applied %d times, want %d", got, tc.wantApplied)
+			}
+		})
+	}
+}
+
+func TestLintConfigResolver(t *testing.T) {
+	l := lint.New(func(string) ([]byte, error) { return []byte("package p\n"), nil }, 0)
+	l.SetConfigResolver(func(dir string) ([]lint.Rule, lint.Config, error) {
+		if dir == "strict" {
+			return []lint.Rule{fileNameRule{}}, lint.Config{
+				Rules: lint.RulesConfig{"file-name": {Severity: lint.SeverityError}},
+			}, nil
+		}
+		return nil, lint.Config{}, nil
+	})
+
+	failures, err := l.Lint([][]string{{"strict/a.go"}, {"lax/b.go"}}, []lint.Rule{fileNameRule{}}, lint.Config{
+		Rules: lint.RulesConfig{"file-name": {Severity: lint.SeverityWarning}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := map[string]lint.Severity{}
+	for f := range failures {
+		got[f.Failure] = f.Severity
+	}
+	want := map[string]lint.Severity{"strict/a.go": lint.SeverityError}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got failures %v, want %v", got, want)
+	}
+}
+
+func TestLintConfigResolverError(t *testing.T) {
+	l := lint.New(func(string) ([]byte, error) { return []byte("package p\n"), nil }, 0)
+	l.SetConfigResolver(func(string) ([]lint.Rule, lint.Config, error) {
+		return nil, lint.Config{}, errors.New("bad config")
+	})
+
+	_, err := l.Lint([][]string{{"p/a.go"}}, []lint.Rule{fileNameRule{}}, lint.Config{})
+	if err == nil || err.Error() != "bad config" {
+		t.Fatalf("expected the resolver error, got %v", err)
+	}
+}
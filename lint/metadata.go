@@ -0,0 +1,40 @@
+package lint
+
+// RuleMetadata is implemented by the rules describing themselves,
+// e.g. for the "revive explain" command and the help text of the formatters.
+type RuleMetadata interface {
+	Rule
+	Metadata() RuleInfo
+}
+
+// RuleInfo describes a rule.
+type RuleInfo struct {
+	// Description is a one-sentence summary of what the rule reports.
+	Description string
+	// Rationale explains why the reported code should be changed.
+	Rationale string `json:",omitempty"`
+	// Category groups related rules, e.g. "naming" or "errors".
+	Category string `json:",omitempty"`
+	// DefaultEnabled is whether the rule is enabled by the default configuration.
+	DefaultEnabled bool
+	// Arguments describe the arguments accepted by the rule, if any.
+	Arguments []RuleArgument `json:",omitempty"`
+	// Examples show code reported by the rule, and the same code once fixed.
+	Examples []RuleExample `json:",omitempty"`
+	// MinGoVersion is the minimum Go version of the code the rule is relevant for, e.g. "1.18", if any.
+	MinGoVersion string `json:",omitempty"`
+}
+
+// RuleArgument describes an argument of a rule.
+type RuleArgument struct {
+	Name string
+	// Type is the TOML type of the argument, e.g. "string" or "list of strings".
+	Type        string
+	Description string
+}
+
+// RuleExample is an example of code reported by a rule.
+type RuleExample struct {
+	Bad  string
+	Good string
+}
@@ -0,0 +1,123 @@
+package lint
+
+import (
+	"context"
+	"go/ast"
+	"go/token"
+	"math"
+	"regexp"
+	"strings"
+)
+
+const (
+	nolintRE        = `^//nolint(?::([\w-]+(?:,[\w-]+)*))?(?:\s+//\s*(.*?))?\s*$`
+	nolintRulesPos  = 1
+	nolintReasonPos = 2
+	// nolintAllRules is the name of revive in golangci-lint
+	nolintAllRules = "revive"
+)
+
+var nolintDirective = regexp.MustCompile(nolintRE)
+
+// nolintIntervals returns the intervals disabled by a golangci-lint style //nolint comment,
+// and false if the comment is not such a directive.
+//
+// As in golangci-lint, a directive disables the rules for the node starting on the line of the comment,
+// or on the next line if the comment is on its own line, e.g. a whole function when the directive is in its doc comment.
+// Rules of other linters are ignored, and "revive" stands for all the rules.
+func (f *File) nolintIntervals(ctx context.Context, group *ast.CommentGroup, c *ast.Comment, rules []Rule, mustSpecifyDisableReason bool, failures chan Failure) ([]DisabledInterval, bool) {
+	match := nolintDirective.FindStringSubmatch(c.Text)
+	if len(match) == 0 {
+		return nil, false
+	}
+
+	ruleNames := f.nolintRuleNames(match[nolintRulesPos], rules)
+	if len(ruleNames) == 0 {
+		return nil, true // the directive is about other linters
+	}
+
+	if mustSpecifyDisableReason && strings.TrimSpace(match[nolintReasonPos]) == "" {
+		sendFailure(ctx, failures, Failure{
+			Confidence: 1,
+			RuleName:   directiveSpecifyDisableReason,
+			Failure:    "reason of lint disabling not found",
+			Position:   ToFailurePosition(c.Pos(), c.End(), f),
+			Node:       c,
+		})
+		return nil, true // skip this linter disabling directive
+	}
+
+	line := f.ToPosition(c.Pos()).Line
+	if !f.isInline(c) {
+		line = f.ToPosition(group.End()).Line + 1
+	}
+	from, to := line, line
+	switch node := f.nodeStartingAt(line).(type) {
+	case nil:
+	case *ast.File:
+		from, to = 1, math.MaxInt32
+	default:
+		to = f.ToPosition(node.End()).Line
+	}
+
+	result := make([]DisabledInterval, 0, len(ruleNames))
+	for _, name := range ruleNames {
+		result = append(result, DisabledInterval{
+			RuleName: name,
+			From:     token.Position{Filename: f.Name, Line: from},
+			To:       token.Position{Filename: f.Name, Line: to, Column: math.MaxInt32},
+			extent:   true,
+		})
+	}
+	return result, true
+}
+
+// nolintRuleNames returns the names of the given rules disabled by a //nolint directive
+// naming the given comma-separated linters.
+func (*File) nolintRuleNames(names string, rules []Rule) []string {
+	all := names == ""
+	named := map[string]bool{}
+	for _, name := range strings.Split(names, ",") {
+		named[name] = true
+		all = all || name == nolintAllRules
+	}
+
+	result := []string{}
+	for _, r := range rules {
+		if all || named[r.Name()] {
+			result = append(result, r.Name())
+		}
+	}
+	return result
+}
+
+// isInline returns true if there is code before the comment on its line.
+func (f *File) isInline(c *ast.Comment) bool {
+	pos := f.ToPosition(c.Pos())
+	lineStart := pos.Offset - (pos.Column - 1)
+	if lineStart < 0 || pos.Offset > len(f.content) {
+		return false
+	}
+	return strings.TrimSpace(string(f.content[lineStart:pos.Offset])) != ""
+}
+
+// nodeStartingAt returns the outermost node starting on the given line, or nil if there is none.
+func (f *File) nodeStartingAt(line int) ast.Node {
+	var result ast.Node
+	ast.Inspect(f.AST, func(n ast.Node) bool {
+		if n == nil || result != nil {
+			return false
+		}
+		switch n.(type) {
+		case *ast.CommentGroup, *ast.Comment:
+			return false
+		}
+		start, end := f.ToPosition(n.Pos()).Line, f.ToPosition(n.End()).Line
+		if start == line {
+			result = n
+			return false
+		}
+		return start < line && line <= end
+	})
+	return result
+}
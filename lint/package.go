@@ -1,11 +1,14 @@
 package lint
 
 import (
+	"context"
+	"errors"
 	"go/ast"
 	"go/importer"
 	"go/token"
 	"go/types"
 	"sync"
+	"time"
 
 	goversion "github.com/hashicorp/go-version"
 
@@ -18,8 +21,15 @@ type Package struct {
 	files     map[string]*File
 	goVersion *goversion.Version
 
-	typesPkg  *types.Package
-	typesInfo *types.Info
+	// importer resolves the imports of the package, defaults to importer.Default()
+	importer   types.Importer
+	typesPkg   *types.Package
+	typesInfo  *types.Info
+	typeErrors []error
+	// sizes are the sizes of the types for the build target, if any
+	sizes types.Sizes
+	// stats, if set, collect the timing statistics of linting
+	stats *Stats
 
 	// sortable is the set of types in the package that implement sort.Interface.
 	sortable map[string]bool
@@ -93,16 +103,29 @@ func (p *Package) TypeCheck() error {
 	if p.typesInfo != nil || p.typesPkg != nil {
 		return nil
 	}
+	start := time.Now()
+	defer func() { p.stats.addTypeCheck(p, time.Since(start)) }()
+	imp := p.importer
+	if imp == nil {
+		imp = importer.Default()
+	}
+	var typeErrors []error
 	config := &types.Config{
-		// By setting a no-op error reporter, the type checker does as much work as possible.
-		Error:    func(error) {},
-		Importer: importer.Default(),
+		// By setting an error reporter, the type checker does as much work as possible.
+		Error: func(err error) {
+			typeErrors = append(typeErrors, err)
+		},
+		Importer: imp,
+		Sizes:    p.sizes,
 	}
 	info := &types.Info{
-		Types:  make(map[ast.Expr]types.TypeAndValue),
-		Defs:   make(map[*ast.Ident]types.Object),
-		Uses:   make(map[*ast.Ident]types.Object),
-		Scopes: make(map[ast.Node]*types.Scope),
+		Types:      make(map[ast.Expr]types.TypeAndValue),
+		Instances:  make(map[*ast.Ident]types.Instance),
+		Defs:       make(map[*ast.Ident]types.Object),
+		Uses:       make(map[*ast.Ident]types.Object),
+		Implicits:  make(map[ast.Node]types.Object),
+		Selections: make(map[*ast.SelectorExpr]*types.Selection),
+		Scopes:     make(map[ast.Node]*types.Scope),
 	}
 	var anyFile *File
 	var astFiles []*ast.File
@@ -117,10 +140,24 @@ func (p *Package) TypeCheck() error {
 	// since we will get partial information.
 	p.typesPkg = typesPkg
 	p.typesInfo = info
+	if ti, ok := imp.(*testImporter); ok {
+		imp = ti.Importer
+	}
+	if mi, ok := imp.(*moduleImporter); ok && mi.err != nil {
+		typeErrors = append([]error{mi.err}, typeErrors...)
+	}
+	p.typeErrors = typeErrors
 
 	return err
 }
 
+// TypeErrors yields the errors found while type checking this package
+func (p *Package) TypeErrors() []error {
+	p.RLock()
+	defer p.RUnlock()
+	return p.typeErrors
+}
+
 // check function encapsulates the call to go/types.Config.Check method and
 // recovers if the called method panics (see issue #59)
 func check(config *types.Config, n string, fset *token.FileSet, astFiles []*ast.File, info *types.Info) (p *types.Package, err error) {
@@ -182,19 +219,92 @@ func (p *Package) scanSortable() {
 	}
 }
 
-func (p *Package) lint(rules []Rule, config Config, failures chan Failure) {
+func (p *Package) lint(ctx context.Context, rules []Rule, config Config, failures chan Failure) {
 	p.scanSortable()
+	if config.TypeCheck.ReportErrors {
+		p.reportTypeErrors(ctx, failures)
+	} else if p.stats != nil {
+		// so that the time of the rules excludes the type checking, counted separately
+		p.TypeCheck()
+	}
+	packageFailures := p.applyPackageRules(ctx, rules, config)
 	var wg sync.WaitGroup
 	for _, file := range p.files {
 		wg.Add(1)
 		go (func(file *File) {
-			file.lint(rules, config, failures)
 			defer wg.Done()
+			file.lint(ctx, rules, config, packageFailures[file.Name], failures)
 		})(file)
 	}
 	wg.Wait()
 }
 
+const typeCheckCategory = "typecheck"
+
+// reportTypeErrors type checks the package and reports the errors as failures.
+func (p *Package) reportTypeErrors(ctx context.Context, failures chan Failure) {
+	p.TypeCheck()
+
+	// errors without position are reported on the first file
+	var anyFile string
+	for name := range p.files {
+		if anyFile == "" || name < anyFile {
+			anyFile = name
+		}
+	}
+
+	for _, err := range p.TypeErrors() {
+		failure := Failure{
+			Confidence: 1,
+			Failure:    err.Error(),
+			RuleName:   typeCheckCategory,
+			Category:   typeCheckCategory,
+			Position:   FailurePosition{Start: token.Position{Filename: anyFile}},
+		}
+		var typeErr types.Error
+		if errors.As(err, &typeErr) {
+			position := typeErr.Fset.Position(typeErr.Pos)
+			failure.Failure = typeErr.Msg
+			failure.Position = FailurePosition{Start: position, End: position}
+		}
+		if !sendFailure(ctx, failures, failure) {
+			return
+		}
+	}
+}
+
+// applyPackageRules applies the package rules and returns their failures indexed by file name.
+func (p *Package) applyPackageRules(ctx context.Context, rules []Rule, config Config) map[string][]Failure {
+	result := map[string][]Failure{}
+	for _, r := range rules {
+		if ctx.Err() != nil {
+			break
+		}
+		packageRule, ok := r.(PackageRule)
+		if !ok {
+			continue
+		}
+
+		start := time.Now()
+		packageFailures := packageRule.ApplyPackage(p, config.Rules[r.Name()].Arguments)
+		p.stats.addRule(r.Name(), time.Since(start), len(p.files), len(packageFailures))
+		for _, failure := range packageFailures {
+			if failure.RuleName == "" {
+				failure.RuleName = r.Name()
+			}
+			if failure.Node != nil {
+				failure.Position = FailurePosition{
+					Start: p.fset.Position(failure.Node.Pos()),
+					End:   p.fset.Position(failure.Node.End()),
+				}
+			}
+			filename := failure.GetFilename()
+			result[filename] = append(result[filename], failure)
+		}
+	}
+	return result
+}
+
 // IsAtLeastGo121 returns true if the Go version for this package is 1.21 or higher, false otherwise
 func (p *Package) IsAtLeastGo121() bool {
 	return p.goVersion.GreaterThanOrEqual(go121)
@@ -0,0 +1,100 @@
+package lint_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/mgechev/revive/lint"
+)
+
+// packageFilesRule reports, on each file, the package it is linted in.
+type packageFilesRule struct{}
+
+func (packageFilesRule) Name() string { return "package-files" }
+
+func (packageFilesRule) Apply(*lint.File, lint.Arguments) []lint.Failure { return nil }
+
+func (packageFilesRule) ApplyPackage(pkg *lint.Package, _ lint.Arguments) []lint.Failure {
+	var failures []lint.Failure
+	for _, file := range pkg.Files() {
+		failures = append(failures, lint.Failure{
+			Confidence: 1,
+			Node:       file.AST.Name,
+			Failure:    fmt.Sprintf("%s in %s with %d files", file.Name, file.AST.Name.Name, len(pkg.Files())),
+		})
+	}
+	return failures
+}
+
+func TestLintExternalTestPackage(t *testing.T) {
+	// the import path of the testdata directory is derived from the go.mod file of revive
+	sources := map[string]string{
+		"testdata/xtest/a.go":           "package xtest\n\nfunc Answer() int { return answer }\n\nvar answer = 42\n",
+		"testdata/xtest/export_test.go": "package xtest\n\nvar Internal = answer\n",
+		"testdata/xtest/a_test.go": `package xtest_test
+
+import (
+	"testing"
+
+	"github.com/mgechev/revive/lint/testdata/xtest"
+)
+
+func TestAnswer(t *testing.T) {
+	if xtest.Answer() != xtest.Internal {
+		t.Fail()
+	}
+}
+`,
+	}
+	filenames := []string{"testdata/xtest/a.go", "testdata/xtest/a_test.go", "testdata/xtest/export_test.go"}
+
+	l := lint.New(func(path string) ([]byte, error) { return []byte(sources[path]), nil }, 0)
+	failures, err := l.Lint([][]string{filenames}, []lint.Rule{packageFilesRule{}}, lint.Config{
+		TypeCheck: lint.TypeCheckConfig{ReportErrors: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for f := range failures {
+		got = append(got, f.Failure)
+	}
+	sort.Strings(got)
+	// no type errors: the external test package sees the declarations of export_test.go
+	want := []string{
+		"testdata/xtest/a.go in xtest with 2 files",
+		"testdata/xtest/a_test.go in xtest_test with 1 files",
+		"testdata/xtest/export_test.go in xtest with 2 files",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got failures %q, want %q", got, want)
+	}
+}
+
+func TestLintExternalTestPackageImportingStandardPackage(t *testing.T) {
+	// without go.mod file, the standard errors package is not the package under test
+	dir := filepath.Join(t.TempDir(), "errors")
+	sources := map[string]string{
+		filepath.Join(dir, "a.go"):      "package errors\n\nfunc Local() {}\n",
+		filepath.Join(dir, "a_test.go"): "package errors_test\n\nimport \"errors\"\n\nvar _ = errors.New(\"x\")\n",
+	}
+	filenames := []string{filepath.Join(dir, "a.go"), filepath.Join(dir, "a_test.go")}
+
+	l := lint.New(func(path string) ([]byte, error) { return []byte(sources[path]), nil }, 0)
+	failures, err := l.Lint([][]string{filenames}, []lint.Rule{packageFilesRule{}}, lint.Config{
+		TypeCheck: lint.TypeCheckConfig{ReportErrors: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for f := range failures {
+		if f.Category == "typecheck" {
+			t.Errorf("unexpected type error %q", f.Failure)
+		}
+	}
+}
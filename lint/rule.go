@@ -9,6 +9,34 @@ type DisabledInterval struct {
 	From     token.Position
 	To       token.Position
 	RuleName string
+	// directive is the revive:disable directive the interval comes from, if any
+	directive *disableDirective
+	// extent is set if From and To are the exact extent of a declaration,
+	// otherwise the interval is made of the whole lines From.Line to To.Line
+	extent bool
+}
+
+// disables returns true if the interval disables the given failure of its rule.
+// A failure is disabled by a declaration interval if it is within the declaration,
+// and by a lines interval if any of its lines is within the interval.
+func (i DisabledInterval) disables(failure Failure) bool {
+	start, end := failure.Position.Start, failure.Position.End
+	if end.Line < start.Line {
+		end = start // the failure has no end position
+	}
+
+	if i.extent {
+		return !isBefore(start, i.From) && !isBefore(i.To, end)
+	}
+	return start.Line <= i.To.Line && end.Line >= i.From.Line
+}
+
+// isBefore returns true if the position a is strictly before b in a file.
+func isBefore(a, b token.Position) bool {
+	if a.Line != b.Line {
+		return a.Line < b.Line
+	}
+	return a.Column < b.Column
 }
 
 // Rule defines an abstract rule interface
@@ -17,6 +45,22 @@ type Rule interface {
 	Apply(*File, Arguments) []Failure
 }
 
+// PackageRule defines an abstract rule needing knowledge of the whole package.
+// Instead of Apply, the linter calls ApplyPackage once per package.
+type PackageRule interface {
+	Rule
+	ApplyPackage(*Package, Arguments) []Failure
+}
+
+// ConfigurableRule defines an abstract configurable rule interface.
+// Configure is called once, before linting, with the arguments of the rule
+// configuration; an error aborts the linting.
+// The linter does not call Configure, a rule used without being configured
+// should configure itself from the arguments passed to Apply.
+type ConfigurableRule interface {
+	Configure(Arguments) error
+}
+
 // AbstractRule defines an abstract rule.
 type AbstractRule struct {
 	Failures []Failure
@@ -29,3 +73,11 @@ func ToFailurePosition(start, end token.Pos, file *File) FailurePosition {
 		End:   file.ToPosition(end),
 	}
 }
+
+// ToEdit returns an edit replacing the source between start and end by newText.
+func ToEdit(start, end token.Pos, newText string, file *File) Edit {
+	return Edit{
+		Position: ToFailurePosition(start, end, file),
+		NewText:  newText,
+	}
+}
@@ -0,0 +1,149 @@
+package lint
+
+import (
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Stats collects timing statistics of linting, it is safe for concurrent use.
+// The packages replayed from the cache are not accounted for.
+// The packages are type checked before the rules are applied, whether they need it or not.
+type Stats struct {
+	mu       sync.Mutex
+	rules    map[string]*RuleStats
+	packages map[packageKey]*PackageStats
+}
+
+// RuleStats are the statistics of a rule.
+type RuleStats struct {
+	Name string
+	// Duration is the total wall time spent applying the rule, type checking excluded.
+	Duration time.Duration
+	// Files is the number of files the rule was applied to.
+	Files int
+	// Failures is the number of failures found by the rule, including those then disabled or excluded.
+	Failures int
+}
+
+// PackageStats are the statistics of a package.
+type PackageStats struct {
+	Dir  string
+	Name string
+	// Files is the number of parsed files of the package.
+	Files     int
+	Parse     time.Duration
+	TypeCheck time.Duration
+}
+
+type packageKey struct {
+	dir, name string
+}
+
+// NewStats returns empty statistics.
+func NewStats() *Stats {
+	return &Stats{
+		rules:    map[string]*RuleStats{},
+		packages: map[packageKey]*PackageStats{},
+	}
+}
+
+// Rules returns the statistics of the rules, the slowest first.
+func (s *Stats) Rules() []RuleStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]RuleStats, 0, len(s.rules))
+	for _, rule := range s.rules {
+		result = append(result, *rule)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Duration != result[j].Duration {
+			return result[i].Duration > result[j].Duration
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// Packages returns the statistics of the packages, the slowest to parse and type check first.
+func (s *Stats) Packages() []PackageStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]PackageStats, 0, len(s.packages))
+	for _, pkg := range s.packages {
+		result = append(result, *pkg)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		di, dj := result[i].Parse+result[i].TypeCheck, result[j].Parse+result[j].TypeCheck
+		if di != dj {
+			return di > dj
+		}
+		if result[i].Dir != result[j].Dir {
+			return result[i].Dir < result[j].Dir
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// addRule accounts for the application of a rule to the given number of files.
+// Like the other add methods, it does nothing on nil statistics.
+func (s *Stats) addRule(name string, duration time.Duration, files, failures int) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rule, ok := s.rules[name]
+	if !ok {
+		rule = &RuleStats{Name: name}
+		s.rules[name] = rule
+	}
+	rule.Duration += duration
+	rule.Files += files
+	rule.Failures += failures
+}
+
+// addParse accounts for the parsing of the files of a package.
+func (s *Stats) addParse(p *Package, duration time.Duration) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pkg := s.packageStats(p)
+	pkg.Files += len(p.files)
+	pkg.Parse += duration
+}
+
+// addTypeCheck accounts for the type checking of a package.
+func (s *Stats) addTypeCheck(p *Package, duration time.Duration) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.packageStats(p).TypeCheck += duration
+}
+
+// packageStats returns the statistics of the package, s.mu must be held.
+func (s *Stats) packageStats(p *Package) *PackageStats {
+	var key packageKey
+	for filename, file := range p.files {
+		key = packageKey{dir: filepath.Dir(filename), name: file.AST.Name.Name}
+		break
+	}
+
+	pkg, ok := s.packages[key]
+	if !ok {
+		pkg = &PackageStats{Dir: key.dir, Name: key.name}
+		s.packages[key] = pkg
+	}
+	return pkg
+}
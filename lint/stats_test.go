@@ -0,0 +1,75 @@
+package lint_test
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/mgechev/revive/lint"
+)
+
+func TestLintStats(t *testing.T) {
+	sources := map[string]string{
+		"p/a.go":      "package p\n\nfunc a() {}\n\nfunc b() {}\n",
+		"p/b.go":      "package p\n\nfunc c() {}\n",
+		"p/a_test.go": "package p_test\n",
+	}
+	l := lint.New(func(path string) ([]byte, error) { return []byte(sources[path]), nil }, 0)
+	stats := lint.NewStats()
+	l.SetStats(stats)
+
+	failures, err := l.Lint([][]string{{"p/a.go", "p/b.go", "p/a_test.go"}}, []lint.Rule{funcDeclRule{}}, lint.Config{
+		Rules:     lint.RulesConfig{},
+		TypeCheck: lint.TypeCheckConfig{ReportErrors: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range failures {
+	}
+
+	rules := stats.Rules()
+	for i := range rules {
+		rules[i].Duration = 0
+	}
+	wantRules := []lint.RuleStats{{Name: "func-decl", Files: 3, Failures: 3}}
+	if !reflect.DeepEqual(rules, wantRules) {
+		t.Errorf("got rule statistics %+v, want %+v", rules, wantRules)
+	}
+
+	packages := stats.Packages()
+	for i := range packages {
+		packages[i].Parse, packages[i].TypeCheck = 0, 0
+	}
+	// the order depends on the durations
+	sort.Slice(packages, func(i, j int) bool { return packages[i].Name < packages[j].Name })
+	wantPackages := []lint.PackageStats{{Dir: "p", Name: "p", Files: 2}, {Dir: "p", Name: "p_test", Files: 1}}
+	if !reflect.DeepEqual(packages, wantPackages) {
+		t.Errorf("got package statistics %+v, want %+v", packages, wantPackages)
+	}
+}
+
+// typesInfoRule reports the files whose package is not type checked yet.
+type typesInfoRule struct{}
+
+func (typesInfoRule) Name() string { return "types-info" }
+
+func (typesInfoRule) Apply(file *lint.File, _ lint.Arguments) []lint.Failure {
+	if file.Pkg.TypesInfo() != nil {
+		return nil
+	}
+	return []lint.Failure{{Confidence: 1, Node: file.AST.Name, Failure: "not type checked"}}
+}
+
+func TestLintStatsTypeCheckBeforeRules(t *testing.T) {
+	l := lint.New(func(string) ([]byte, error) { return []byte("package p\n"), nil }, 0)
+	l.SetStats(lint.NewStats())
+
+	failures, err := l.Lint([][]string{{"p/a.go", "p/b.go"}}, []lint.Rule{typesInfoRule{}}, lint.Config{Rules: lint.RulesConfig{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for failure := range failures {
+		t.Errorf("got failure %q on %s, want the package type checked before the rules are applied", failure.Failure, failure.GetFilename())
+	}
+}
@@ -0,0 +1,246 @@
+package revivelib
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mgechev/revive/lint"
+)
+
+// baselineVersion is the version of the format of baseline files.
+const baselineVersion = 1
+
+// Baseline is a set of known failures that are not reported again,
+// e.g. the failures of a code base at the time a rule is enabled.
+type Baseline struct {
+	Version int             `json:"version"`
+	Entries []BaselineEntry `json:"entries"`
+
+	// dir is the directory of the baseline file, paths of the entries are relative to it
+	dir string
+	// stale are the entries not matched by the last linting
+	stale []BaselineEntry
+}
+
+// BaselineEntry is a known failure, or Count identical ones.
+type BaselineEntry struct {
+	Fingerprint string `json:"fingerprint"`
+	Rule        string `json:"rule"`
+	File        string `json:"file"`
+	Message     string `json:"message"`
+	Count       int    `json:"count"`
+}
+
+// ReadBaseline reads the baseline file written by WriteBaseline at path.
+func ReadBaseline(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the baseline: %w", err)
+	}
+
+	baseline := &Baseline{}
+	if err := json.Unmarshal(data, baseline); err != nil {
+		return nil, fmt.Errorf("cannot parse the baseline %s: %w", path, err)
+	}
+	if baseline.Version != baselineVersion {
+		return nil, fmt.Errorf("unsupported version %d of the baseline %s, expected %d", baseline.Version, path, baselineVersion)
+	}
+
+	baseline.dir, err = filepath.Abs(filepath.Dir(path))
+	if err != nil {
+		return nil, err
+	}
+	return baseline, nil
+}
+
+// SetBaseline makes Lint hide the failures matching an entry of the baseline.
+// Failures are matched by rule, file, message and source line, so that they
+// still match when the code around them moves.
+func (r *Revive) SetBaseline(baseline *Baseline) {
+	r.baseline = baseline
+}
+
+// Stale returns the entries of the baseline that matched no failure of
+// the last linting, i.e. the failures that were fixed since the baseline
+// was written. Only the entries of the linted files are considered.
+// The result is valid once the channel returned by Lint is closed.
+func (b *Baseline) Stale() []BaselineEntry {
+	return b.stale
+}
+
+// WriteBaseline writes to path a baseline of the given failures,
+// and returns the number of failures in it.
+// Failures reporting errors, and failures below the confidence
+// threshold of the configuration, are not part of the baseline.
+func (r *Revive) WriteBaseline(path string, failures <-chan lint.Failure) (int, error) {
+	dir, err := filepath.Abs(filepath.Dir(path))
+	if err != nil {
+		return 0, err
+	}
+
+	fp := newFingerprinter(dir, r.readFile)
+	entries := map[string]*BaselineEntry{}
+	total := 0
+	for failure := range failures {
+		if failure.Category == lint.FailureCategoryError || failure.Confidence < r.configOf(failure).Confidence {
+			continue
+		}
+
+		entry := fp.entry(failure)
+		if e, ok := entries[entry.Fingerprint]; ok {
+			e.Count++
+		} else {
+			entries[entry.Fingerprint] = &entry
+		}
+		total++
+	}
+
+	baseline := Baseline{Version: baselineVersion, Entries: []BaselineEntry{}}
+	for _, entry := range entries {
+		baseline.Entries = append(baseline.Entries, *entry)
+	}
+	sortBaselineEntries(baseline.Entries)
+
+	data, err := json.MarshalIndent(baseline, "", "  ")
+	if err != nil {
+		return 0, err
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return 0, fmt.Errorf("cannot write the baseline: %w", err)
+	}
+	return total, nil
+}
+
+// filterBaseline forwards to the returned channel the failures not in the baseline,
+// and records the stale entries of the baseline once failures is closed.
+// The source lines of the failures are read with readFile.
+func filterBaseline(ctx context.Context, baseline *Baseline, packages [][]string, readFile lint.ReadFile, failures <-chan lint.Failure) <-chan lint.Failure {
+	fp := newFingerprinter(baseline.dir, readFile)
+	remaining := map[string]int{}
+	for _, entry := range baseline.Entries {
+		remaining[entry.Fingerprint] += entry.Count
+	}
+
+	result := make(chan lint.Failure)
+	go func() {
+		defer close(result)
+		for failure := range failures {
+			if failure.Category != lint.FailureCategoryError {
+				fingerprint := fp.entry(failure).Fingerprint
+				if remaining[fingerprint] > 0 {
+					remaining[fingerprint]--
+					continue
+				}
+			}
+
+			select {
+			case result <- failure:
+			case <-ctx.Done():
+			}
+		}
+
+		linted := map[string]bool{}
+		for _, files := range packages {
+			for _, file := range files {
+				linted[fp.relPath(file)] = true
+			}
+		}
+		stale := []BaselineEntry{}
+		for _, entry := range baseline.Entries {
+			if n := remaining[entry.Fingerprint]; n > 0 && linted[entry.File] {
+				entry.Count = n
+				stale = append(stale, entry)
+				remaining[entry.Fingerprint] = 0
+			}
+		}
+		sortBaselineEntries(stale)
+		baseline.stale = stale
+	}()
+	return result
+}
+
+func sortBaselineEntries(entries []BaselineEntry) {
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Rule != b.Rule {
+			return a.Rule < b.Rule
+		}
+		return a.Fingerprint < b.Fingerprint
+	})
+}
+
+// fingerprinter computes the baseline entries of failures.
+type fingerprinter struct {
+	// dir is the directory the file paths are made relative to
+	dir string
+	// readFile reads the files of the failures, as they were linted
+	readFile lint.ReadFile
+	// lines caches the lines of the files of the failures
+	lines map[string][][]byte
+}
+
+func newFingerprinter(dir string, readFile lint.ReadFile) *fingerprinter {
+	return &fingerprinter{dir: dir, readFile: readFile, lines: map[string][][]byte{}}
+}
+
+// entry returns the baseline entry of a failure. Its fingerprint
+// does not depend on the position of the failure, only on the rule,
+// the file, the message and the normalized source line of the failure.
+func (fp *fingerprinter) entry(failure lint.Failure) BaselineEntry {
+	file := fp.relPath(failure.GetFilename())
+	snippet := fp.snippet(failure.GetFilename(), failure.Position.Start.Line)
+
+	hash := sha256.New()
+	for _, s := range []string{failure.RuleName, file, failure.Failure, snippet} {
+		hash.Write([]byte(s))
+		hash.Write([]byte{0})
+	}
+
+	return BaselineEntry{
+		Fingerprint: hex.EncodeToString(hash.Sum(nil)[:16]),
+		Rule:        failure.RuleName,
+		File:        file,
+		Message:     failure.Failure,
+		Count:       1,
+	}
+}
+
+// relPath returns the slash-separated path of filename relative to the directory of the baseline.
+func (fp *fingerprinter) relPath(filename string) string {
+	abs, err := filepath.Abs(filename)
+	if err != nil {
+		return filepath.ToSlash(filename)
+	}
+	rel, err := filepath.Rel(fp.dir, abs)
+	if err != nil {
+		return filepath.ToSlash(abs)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// snippet returns the given line of the file, with its whitespace normalized.
+func (fp *fingerprinter) snippet(filename string, line int) string {
+	lines, ok := fp.lines[filename]
+	if !ok {
+		content, err := fp.readFile(filename)
+		if err == nil {
+			lines = bytes.Split(content, []byte("\n"))
+		}
+		fp.lines[filename] = lines
+	}
+	if line < 1 || line > len(lines) {
+		return ""
+	}
+	return strings.Join(strings.Fields(string(lines[line-1])), " ")
+}
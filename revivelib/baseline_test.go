@@ -0,0 +1,127 @@
+package revivelib_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mgechev/revive/revivelib"
+)
+
+func TestReviveBaseline(t *testing.T) {
+	// ARRANGE
+	original, err := os.ReadFile("../testdata/if-return.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	file := filepath.Join(dir, "if-return.go")
+	if err := os.WriteFile(file, original, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	baselinePath := filepath.Join(dir, "baseline.json")
+
+	revive := getMockRevive(t)
+	failures, err := revive.Lint(revivelib.Include(file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	count, err := revive.WriteBaseline(baselinePath, failures)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 5 {
+		t.Fatalf("Expected a baseline of 5 failures, but got %d.", count)
+	}
+
+	// move all the failures down by one line, and change the source line of the first one
+	changed := "// moved\n" + strings.Replace(string(original), "err := f(); err != nil { // MATCH", "err := g(); err != nil { // MATCH", 1)
+	if err := os.WriteFile(file, []byte(changed), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// ACT
+	baseline, err := revivelib.ReadBaseline(baselinePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	revive = getMockRevive(t)
+	revive.SetBaseline(baseline)
+	failures, err = revive.Lint(revivelib.Include(file))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// ASSERT
+	lines := []int{}
+	for failure := range failures {
+		lines = append(lines, failure.Position.Start.Line)
+	}
+	if len(lines) != 1 || lines[0] != 16 {
+		t.Fatalf("Expected a single failure on line 16, but got failures on lines %v.", lines)
+	}
+
+	stale := baseline.Stale()
+	if len(stale) != 1 || stale[0].File != "if-return.go" || stale[0].Rule != "if-return" {
+		t.Fatalf("Expected a single stale if-return entry for if-return.go, but got %+v.", stale)
+	}
+}
+
+func TestReviveBaselineOverlay(t *testing.T) {
+	// ARRANGE
+	original, err := os.ReadFile("../testdata/if-return.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	file := filepath.Join(dir, "if-return.go")
+	if err := os.WriteFile(file, original, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	baselinePath := filepath.Join(dir, "baseline.json")
+
+	revive := getMockRevive(t)
+	failures, err := revive.Lint(revivelib.Include(file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := revive.WriteBaseline(baselinePath, failures); err != nil {
+		t.Fatal(err)
+	}
+
+	// the file on disk no longer matches the linted content
+	if err := os.WriteFile(file, []byte("// moved\n"+string(original)), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// ACT
+	baseline, err := revivelib.ReadBaseline(baselinePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	revive = getMockRevive(t)
+	revive.SetBaseline(baseline)
+	failures, err = revive.LintBuffer(context.Background(), file, original)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// ASSERT
+	for failure := range failures {
+		t.Errorf("Expected the failures of the buffer to be in the baseline, but got %q on line %d.", failure.Failure, failure.Position.Start.Line)
+	}
+}
+
+func TestReadBaselineUnsupportedVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	if err := os.WriteFile(path, []byte(`{"version": 42, "entries": []}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := revivelib.ReadBaseline(path)
+	if err == nil || !strings.Contains(err.Error(), "unsupported version 42") {
+		t.Fatalf("Expected an unsupported version error, but got %v.", err)
+	}
+}
@@ -0,0 +1,58 @@
+package revivelib
+
+import (
+	"fmt"
+	"os"
+	"runtime/debug"
+	"strings"
+
+	"github.com/mgechev/revive/internal/cache"
+)
+
+const reviveModulePath = "github.com/mgechev/revive"
+
+// DefaultCacheDir returns the default directory of the lint results cache,
+// i.e. $XDG_CACHE_HOME/revive on Unix systems.
+func DefaultCacheDir() (string, error) {
+	return cache.DefaultDir()
+}
+
+// EnableCache makes Lint replay the failures of the packages whose files,
+// configuration and Go version did not change since they were linted,
+// from a cache stored in dir.
+func (r *Revive) EnableCache(dir string) {
+	r.cache = cache.New(dir, reviveVersion())
+}
+
+// CleanCache removes the lint results cached in dir.
+func CleanCache(dir string) error {
+	return cache.Clean(dir)
+}
+
+// reviveVersion identifies the revive build, so that results cached
+// by a build are not replayed by another one.
+func reviveVersion() string {
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		mod := &bi.Main
+		for _, dep := range bi.Deps {
+			if dep.Path == reviveModulePath {
+				mod = dep
+			}
+		}
+		released := mod.Version != "" && mod.Version != "(devel)" && !strings.HasSuffix(mod.Version, "+dirty")
+		if mod.Path == reviveModulePath && mod.Replace == nil && released {
+			return mod.Version
+		}
+	}
+
+	// development builds are told apart by their executable
+	exe, err := os.Executable()
+	if err != nil {
+		return "devel"
+	}
+	info, err := os.Stat(exe)
+	if err != nil {
+		return "devel"
+	}
+	return fmt.Sprintf("devel %s %d %d", exe, info.Size(), info.ModTime().UnixNano())
+}
@@ -2,12 +2,14 @@
 package revivelib
 
 import (
+	"context"
 	"log"
-	"os"
 	"strings"
+	"sync"
 
 	"github.com/mgechev/dots"
 	"github.com/mgechev/revive/config"
+	"github.com/mgechev/revive/internal/diff"
 	"github.com/mgechev/revive/lint"
 	"github.com/mgechev/revive/logging"
 	"github.com/pkg/errors"
@@ -20,6 +22,22 @@ type Revive struct {
 	lintingRules []lint.Rule
 	logger       *log.Logger
 	maxOpenFiles int
+	cache        lint.Cache
+	// changes, if set, are the only lines on which failures are reported
+	changes diff.Changes
+	// baseline, if set, holds the known failures that are not reported
+	baseline *Baseline
+	// sortOrder is the order of the failures passed to the formatter
+	sortOrder SortOrder
+	// extraRules are the rules given to New besides the revive ones
+	extraRules []lint.Rule
+	// hierarchy, if set, resolves the configuration of each package
+	hierarchy *config.Hierarchy
+	// overlay holds the contents read instead of those of the files, by cleaned path
+	overlay   map[string][]byte
+	overlayMu sync.RWMutex
+	// stats, if set, collect the timing statistics of linting
+	stats *lint.Stats
 }
 
 // New creates a new instance of Revive lint runner.
@@ -63,11 +81,63 @@ func New(
 		config:       conf,
 		lintingRules: lintingRules,
 		maxOpenFiles: maxOpenFiles,
+		extraRules:   extraRuleInstances,
 	}, nil
 }
 
+// EnableNestedConfig makes Lint lint each package with the configuration files
+// (see config.FindConfigFile) of its directory and of its ancestors, up to the root of the repository,
+// merged on top of the configuration given to New, read from configPath if not empty.
+// The nearest file wins, and a file with "root = true" stops the inheritance.
+func (r *Revive) EnableNestedConfig(configPath string) {
+	r.hierarchy = config.NewHierarchy(r.config, configPath, r.lintingRules, r.extraRules)
+}
+
+// EnableStats makes Lint collect timing statistics, returned by Stats.
+func (r *Revive) EnableStats() {
+	r.stats = lint.NewStats()
+}
+
+// Stats returns the timing statistics collected since EnableStats, nil if not enabled.
+// They are complete once the failures returned by Lint are all received.
+func (r *Revive) Stats() *lint.Stats {
+	return r.stats
+}
+
+// ExcludeIssues returns the issue exclusions of the configuration, indexed by the path
+// of their configuration file: the configuration given to New under the empty path,
+// then the nested configuration files used by the last linting.
+// Their Suppressed counts are those of the last linting.
+func (r *Revive) ExcludeIssues() map[string][]lint.IssueExclusion {
+	result := map[string][]lint.IssueExclusion{}
+	if r.hierarchy != nil {
+		result = r.hierarchy.ExcludeIssues()
+	}
+	if len(r.config.ExcludeIssues) > 0 {
+		result[""] = r.config.ExcludeIssues
+	}
+	return result
+}
+
 // Lint the included patterns, skipping excluded ones
 func (r *Revive) Lint(patterns ...*LintPattern) (<-chan lint.Failure, error) {
+	return r.LintContext(context.Background(), patterns...)
+}
+
+// LintContext lints the included patterns, skipping excluded ones.
+// Linting stops, and the returned channel is closed, when ctx is done.
+func (r *Revive) LintContext(ctx context.Context, patterns ...*LintPattern) (<-chan lint.Failure, error) {
+	packages, err := r.Packages(patterns...)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.LintPackages(ctx, packages)
+}
+
+// Packages returns the packages of the included patterns, skipping excluded ones,
+// each one being the list of its files.
+func (r *Revive) Packages(patterns ...*LintPattern) ([][]string, error) {
 	includePatterns := []string{}
 	excludePatterns := []string{}
 
@@ -88,21 +158,38 @@ func (r *Revive) Lint(patterns ...*LintPattern) (<-chan lint.Failure, error) {
 		return nil, errors.Wrap(err, "linting - getting packages")
 	}
 
-	revive := lint.New(func(file string) ([]byte, error) {
-		contents, err := os.ReadFile(file)
+	return packages, nil
+}
 
-		if err != nil {
-			return nil, errors.Wrap(err, "reading file "+file)
+// LintPackages lints the given packages, each one being a list of files, e.g. as returned by Packages.
+// Linting stops, and the returned channel is closed, when ctx is done.
+func (r *Revive) LintPackages(ctx context.Context, packages [][]string) (<-chan lint.Failure, error) {
+	for _, exclusions := range r.ExcludeIssues() {
+		for i := range exclusions {
+			exclusions[i].ResetSuppressed()
 		}
+	}
 
-		return contents, nil
-	}, r.maxOpenFiles)
+	revive := lint.New(r.readFile, r.maxOpenFiles)
+	revive.SetCache(r.cache)
+	revive.SetStats(r.stats)
+	if r.hierarchy != nil {
+		revive.SetConfigResolver(r.hierarchy.Resolve)
+	}
 
-	failures, err := revive.Lint(packages, r.lintingRules, *r.config)
+	failures, err := revive.LintContext(ctx, packages, r.lintingRules, *r.config)
 	if err != nil {
 		return nil, errors.Wrap(err, "linting - retrieving failures channel")
 	}
 
+	if r.baseline != nil {
+		failures = filterBaseline(ctx, r.baseline, packages, r.readFile, failures)
+	}
+
+	if r.changes != nil {
+		failures = filterNew(ctx, r.changes, failures)
+	}
+
 	return failures, nil
 }
 
@@ -111,7 +198,9 @@ func (r *Revive) Format(
 	formatterName string,
 	failuresChan <-chan lint.Failure,
 ) (string, int, error) {
-	conf := r.config
+	// a copy, so that the descriptions of the rules are given to the formatter only
+	conf := *r.config
+	conf.RulesInfo = config.GetRulesInfo(r.config, r.extraRules)
 	formatChan := make(chan lint.Failure)
 	exitChan := make(chan bool)
 
@@ -126,15 +215,18 @@ func (r *Revive) Format(
 	)
 
 	go func() {
-		output, formatErr = formatter.Format(formatChan, *conf)
+		output, formatErr = formatter.Format(formatChan, conf)
 
 		exitChan <- true
 	}()
 
 	exitCode := 0
+	order := r.sortOrderFor(formatter)
+	var sorted []lint.Failure
 
 	for failure := range failuresChan {
-		if failure.Confidence < conf.Confidence {
+		failureConf := r.configOf(failure)
+		if failure.Confidence < failureConf.Confidence {
 			continue
 		}
 
@@ -142,17 +234,23 @@ func (r *Revive) Format(
 			exitCode = conf.WarningCode
 		}
 
-		if c, ok := conf.Rules[failure.RuleName]; ok && c.Severity == lint.SeverityError {
+		if isError(failureConf, failure) {
 			exitCode = conf.ErrorCode
 		}
 
-		if c, ok := conf.Directives[failure.RuleName]; ok && c.Severity == lint.SeverityError {
-			exitCode = conf.ErrorCode
+		if order != SortNone {
+			sorted = append(sorted, failure)
+			continue
 		}
 
 		formatChan <- failure
 	}
 
+	sortFailures(sorted, order, &conf)
+	for _, failure := range sorted {
+		formatChan <- failure
+	}
+
 	close(formatChan)
 	<-exitChan
 
@@ -1,6 +1,8 @@
 package revivelib
 
 import (
+	"go/token"
+	"reflect"
 	"testing"
 
 	"github.com/mgechev/revive/config"
@@ -67,3 +69,28 @@ func getMockRevive(t *testing.T) *Revive {
 
 	return revive
 }
+
+func TestSortFailuresBySeverity(t *testing.T) {
+	conf := &lint.Config{Rules: lint.RulesConfig{"b-rule": {Severity: lint.SeverityError}}}
+	at := func(rule, file string, line int) lint.Failure {
+		return lint.Failure{RuleName: rule, Position: lint.FailurePosition{Start: token.Position{Filename: file, Line: line}}}
+	}
+	failures := []lint.Failure{
+		at("a-rule", "b.go", 1),
+		at("b-rule", "b.go", 2),
+		at("a-rule", "a.go", 3),
+		at("b-rule", "a.go", 4),
+		{RuleName: "a-rule", Category: lint.FailureCategoryError, Position: lint.FailurePosition{Start: token.Position{Filename: "c.go"}}},
+	}
+
+	sortFailures(failures, SortSeverity, conf)
+
+	got := []string{}
+	for _, f := range failures {
+		got = append(got, f.Position.Start.String())
+	}
+	want := []string{"a.go:4", "b.go:2", "c.go", "a.go:3", "b.go:1"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Expected failures sorted as %v, but got %v.", want, got)
+	}
+}
@@ -1,6 +1,11 @@
 package revivelib_test
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -36,6 +41,63 @@ func TestReviveLint(t *testing.T) {
 	}
 }
 
+func TestReviveLintContextCancelled(t *testing.T) {
+	// ARRANGE
+	revive := getMockRevive(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// ACT
+	failures, err := revive.LintContext(ctx, revivelib.Include("../testdata/if-return.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// ASSERT
+	got := 0
+	for range failures {
+		got++
+	}
+
+	if got != 0 {
+		t.Fatalf("Expected no failures after cancellation, but got %d.", got)
+	}
+}
+
+func TestReviveNewFromPatch(t *testing.T) {
+	// ARRANGE
+	revive := getMockRevive(t)
+	patch := `diff --git a/../testdata/if-return.go b/../testdata/if-return.go
+--- a/../testdata/if-return.go
++++ b/../testdata/if-return.go
+@@ -14,0 +15 @@
++	if err := dangerous(); err != nil {
+@@ -90 +91 @@
+-		return err
++		return nil
+`
+	if err := revive.NewFromPatch([]byte(patch)); err != nil {
+		t.Fatal(err)
+	}
+
+	// ACT
+	failures, err := revive.Lint(revivelib.Include("../testdata/if-return.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// ASSERT
+	got := map[int]string{}
+	for failure := range failures {
+		got[failure.Position.Start.Line] = failure.RuleName
+	}
+
+	want := map[int]string{15: "if-return", 91: "unreachable-code"}
+	if len(got) != len(want) || got[15] != want[15] || got[91] != want[91] {
+		t.Fatalf("Expected failures %v, but got %v.", want, got)
+	}
+}
+
 func TestReviveFormat(t *testing.T) {
 	// ARRANGE
 	revive := getMockRevive(t)
@@ -72,6 +134,107 @@ func TestReviveFormat(t *testing.T) {
 	}
 }
 
+func TestReviveFormatSorted(t *testing.T) {
+	tests := []struct {
+		order revivelib.SortOrder
+		want  []int
+	}{
+		{order: revivelib.SortDefault, want: []int{15, 88, 91, 95, 98}},
+		{order: revivelib.SortRule, want: []int{15, 88, 95, 91, 98}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.order), func(t *testing.T) {
+			// ARRANGE
+			revive := getMockRevive(t)
+			revive.SetSortOrder(tt.order)
+
+			failuresChan, err := revive.Lint(revivelib.Include("../testdata/if-return.go"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// ACT
+			output, _, err := revive.Format("default", failuresChan)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// ASSERT
+			want := ""
+			for _, line := range tt.want {
+				msg := "redundant if ...; err != nil check, just return error instead."
+				column := 3
+				if line == 91 || line == 98 {
+					msg = "unreachable code after this statement"
+				}
+				if line == 15 {
+					column = 2
+				}
+				want += fmt.Sprintf("../testdata/if-return.go:%d:%d: %s\n", line, column, msg)
+			}
+			if output != want {
+				t.Fatalf("Expected output\n%s\nbut got\n%s", want, output)
+			}
+		})
+	}
+}
+
+func TestParseSortOrder(t *testing.T) {
+	if order, err := revivelib.ParseSortOrder("severity"); err != nil || order != revivelib.SortSeverity {
+		t.Fatalf("Expected the severity sort order, but got %q, %v.", order, err)
+	}
+	if _, err := revivelib.ParseSortOrder("random"); err == nil {
+		t.Fatal("Expected an error for an unknown sort order.")
+	}
+}
+
+func TestReviveFixDryRun(t *testing.T) {
+	// ARRANGE
+	conf := &lint.Config{
+		Confidence: 0.8,
+		Rules:      lint.RulesConfig{"use-any": {}},
+	}
+	revive, err := revivelib.New(conf, false, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	failuresChan, err := revive.Lint(revivelib.Include("../testdata/fix/use-any.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// ACT
+	var diff strings.Builder
+	remaining, err := revive.Fix(failuresChan, true, &diff)
+	// ASSERT
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	count := 0
+	for failure := range remaining {
+		if !failure.IsFixable() {
+			t.Errorf("Expected only the fixable failures, but got %q", failure.Failure)
+		}
+		count++
+	}
+	if count == 0 {
+		t.Error("Expected the failures to be reported, since none was fixed")
+	}
+
+	changes := []string{
+		"-var x interface{}\n+var x any\n",
+		"-func f(a interface{}, b []interface{}) map[string]interface{} {\n+func f(a any, b []any) map[string]any {\n",
+	}
+	for _, change := range changes {
+		if !strings.Contains(diff.String(), change) {
+			t.Fatalf("Expected diff\n'%s'\nto contain\n'%s', but it didn't.", diff.String(), change)
+		}
+	}
+}
+
 type mockRule struct{}
 
 func (r *mockRule) Name() string {
@@ -103,3 +266,231 @@ func getMockRevive(t *testing.T) *revivelib.Revive {
 
 	return revive
 }
+
+func TestReviveFormatOverriddenSeverity(t *testing.T) {
+	// ARRANGE
+	path := filepath.Join(t.TempDir(), "revive.toml")
+	const toml = `
+errorCode = 2
+warningCode = 1
+[rule.if-return]
+  severity = "warning"
+[[rule.if-return.override]]
+  paths = ["cmd/**"]
+  disabled = true
+[[rule.if-return.override]]
+  paths = ["~testdata/if-return\\.go$"]
+  severity = "error"
+`
+	if err := os.WriteFile(path, []byte(toml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	conf, err := config.GetConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	revive, err := revivelib.New(conf, false, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	failuresChan, err := revive.Lint(revivelib.Include("../testdata/if-return.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// ACT
+	output, exitCode, err := revive.Format("json", failuresChan)
+
+	// ASSERT
+	if err != nil {
+		t.Fatal(err)
+	}
+	var failures []struct{ Severity string }
+	if err := json.Unmarshal([]byte(output), &failures); err != nil {
+		t.Fatal(err)
+	}
+	if len(failures) == 0 {
+		t.Fatal("Expected failures, got none")
+	}
+	for _, f := range failures {
+		if f.Severity != lint.SeverityError {
+			t.Errorf("Expected the overridden severity %q, got %q", lint.SeverityError, f.Severity)
+		}
+	}
+	if exitCode != 2 {
+		t.Errorf("Expected exit code to be 2, but it was %d.", exitCode)
+	}
+}
+
+func TestReviveFormatRuleHelp(t *testing.T) {
+	// ARRANGE
+	path := filepath.Join(t.TempDir(), "revive.toml")
+	const toml = `
+[rule.imports-blacklist]
+  arguments = ["crypto/md5"]
+`
+	if err := os.WriteFile(path, []byte(toml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	conf, err := config.GetConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	revive, err := revivelib.New(conf, false, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	failures := make(chan lint.Failure)
+	close(failures)
+
+	// ACT
+	output, _, err := revive.Format("sarif", failures)
+
+	// ASSERT
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the rule configured with its former name is described
+	want := (&rule.ImportsBlocklistRule{}).Metadata().Description
+	if !strings.Contains(output, want) {
+		t.Errorf("Expected the SARIF output\n%s\nto contain the description %q", output, want)
+	}
+	if conf.RulesInfo != nil {
+		t.Errorf("Expected the configuration to be left unchanged, got the rules info %v", conf.RulesInfo)
+	}
+}
+
+// writeFiles creates the given files, and their directories, in dir.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// newNestedRevive writes the given files in the root of a new repository, and returns
+// the linter configured by its revive.toml file, with the nested configurations enabled,
+// and the root directory.
+func newNestedRevive(t *testing.T, files map[string]string) (*revivelib.Revive, string) {
+	t.Helper()
+	dir := t.TempDir()
+	writeFiles(t, dir, files)
+	writeFiles(t, dir, map[string]string{".git/HEAD": ""})
+	basePath := filepath.Join(dir, "revive.toml")
+	conf, err := config.GetConfig(basePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	revive, err := revivelib.New(conf, false, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	revive.EnableNestedConfig(basePath)
+	return revive, dir
+}
+
+func TestReviveFormatNestedConfig(t *testing.T) {
+	// ARRANGE
+	revive, dir := newNestedRevive(t, map[string]string{
+		"revive.toml":     "confidence = 0.95\n[rule.error-return]\n",
+		"sub/revive.toml": "confidence = 0.5\nseverity = \"error\"\n",
+		"sub/x.go":        "package sub\n\nfunc f() (error, int) { return nil, 0 }\n",
+	})
+
+	failuresChan, err := revive.Lint(revivelib.Include(filepath.Join(dir, "sub", "x.go")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// ACT
+	output, _, err := revive.Format("json", failuresChan)
+
+	// ASSERT
+	if err != nil {
+		t.Fatal(err)
+	}
+	var failures []struct{ RuleName, Severity string }
+	if err := json.Unmarshal([]byte(output), &failures); err != nil {
+		t.Fatal(err)
+	}
+	// the failure is below the confidence of the base configuration, not of the nested one
+	if len(failures) != 1 || failures[0].RuleName != "error-return" || failures[0].Severity != lint.SeverityError {
+		t.Fatalf("Expected an error-return failure with the nested severity, got %+v", failures)
+	}
+}
+
+func TestReviveExcludeIssuesNestedConfig(t *testing.T) {
+	// ARRANGE
+	const src = "package p\n\nfunc f() (error, int) { return nil, 0 }\n"
+	revive, dir := newNestedRevive(t, map[string]string{
+		"revive.toml":     "confidence = 0.5\n[rule.error-return]\n",
+		"sub/revive.toml": "[[exclude-issue]]\nrule = \"error-return\"\n",
+		"sub/a/x.go":      src,
+		"sub/b/x.go":      src,
+	})
+
+	for run := 1; run <= 2; run++ {
+		// ACT
+		failures, err := revive.Lint(revivelib.Include(filepath.Join(dir, "sub", "...")))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for failure := range failures {
+			t.Errorf("Expected the failures to be excluded, got %q", failure.Failure)
+		}
+
+		// ASSERT
+		exclusions := revive.ExcludeIssues()[filepath.Join(dir, "sub", "revive.toml")]
+		if len(exclusions) != 1 || exclusions[0].Suppressed() != 2 {
+			t.Fatalf("run %d: expected the nested exclusion to suppress the 2 failures, got %+v", run, exclusions)
+		}
+	}
+}
+
+func TestReviveLintBuffer(t *testing.T) {
+	// ARRANGE
+	dir := t.TempDir()
+	sibling := "package p\n\nfunc helper() int { return 1 }\n"
+	if err := os.WriteFile(filepath.Join(dir, "helper.go"), []byte(sibling), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// the file on disk is outdated, the buffer replaces it
+	filename := filepath.Join(dir, "main.go")
+	if err := os.WriteFile(filename, []byte("package p\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	const buffer = "package p\n\nvar value = helper() + undefined\n"
+
+	conf := &lint.Config{
+		Confidence: 0.8,
+		Rules:      lint.RulesConfig{},
+		TypeCheck:  lint.TypeCheckConfig{ReportErrors: true},
+	}
+	revive, err := revivelib.New(conf, false, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// ACT
+	failures, err := revive.LintBuffer(context.Background(), filename, []byte(buffer))
+
+	// ASSERT
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for f := range failures {
+		got = append(got, fmt.Sprintf("%s:%d: %s", f.GetFilename(), f.Position.Start.Line, f.Failure))
+	}
+	want := []string{filename + ":3: undefined: undefined"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("Expected failures %q, got %q", want, got)
+	}
+}
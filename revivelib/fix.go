@@ -0,0 +1,98 @@
+package revivelib
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/mgechev/revive/internal/diff"
+	"github.com/mgechev/revive/lint"
+	"github.com/pkg/errors"
+)
+
+// Fix applies the suggested fixes of the failures coming from Lint and
+// returns a channel yielding the failures that were not fixed.
+//
+// Fixes are applied atomically per file, non overlapping fixes only.
+// If dryRun is true, files are left untouched, the unified diff of
+// the changes that would have been applied is written to diffOut and,
+// since nothing was fixed, the returned channel yields all the failures.
+func (r *Revive) Fix(failuresChan <-chan lint.Failure, dryRun bool, diffOut io.Writer) (<-chan lint.Failure, error) {
+	remaining := []lint.Failure{}
+	fixable := map[string][]lint.Failure{}
+	for failure := range failuresChan {
+		if !failure.IsFixable() || failure.Confidence < r.configOf(failure).Confidence {
+			remaining = append(remaining, failure)
+			continue
+		}
+		filename := failure.GetFilename()
+		fixable[filename] = append(fixable[filename], failure)
+	}
+
+	filenames := make([]string, 0, len(fixable))
+	for filename := range fixable {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+
+	for _, filename := range filenames {
+		content, err := os.ReadFile(filename)
+		if err != nil {
+			return nil, errors.Wrap(err, "fixing - reading file "+filename)
+		}
+
+		fixed, notFixed := lint.ApplyFixes(content, fixable[filename])
+
+		if dryRun {
+			remaining = append(remaining, fixable[filename]...)
+			if _, err := io.WriteString(diffOut, diff.Unified(filename, content, fixed)); err != nil {
+				return nil, errors.Wrap(err, "fixing - writing diff")
+			}
+			continue
+		}
+
+		remaining = append(remaining, notFixed...)
+
+		if err := writeFileAtomically(filename, fixed); err != nil {
+			return nil, errors.Wrap(err, "fixing - writing file "+filename)
+		}
+	}
+
+	result := make(chan lint.Failure, len(remaining))
+	for _, failure := range remaining {
+		result <- failure
+	}
+	close(result)
+
+	return result, nil
+}
+
+// writeFileAtomically replaces the content of the named file by writing
+// a temporary file in the same directory and renaming it over the original.
+func writeFileAtomically(filename string, content []byte) error {
+	info, err := os.Stat(filename)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(filename), "."+filepath.Base(filename)+".revive-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // no-op once renamed
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(info.Mode().Perm()); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), filename)
+}
@@ -0,0 +1,97 @@
+package revivelib
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/mgechev/revive/internal/diff"
+	"github.com/mgechev/revive/lint"
+)
+
+// NewFromRev makes Lint report only the failures on lines added or changed
+// since the given git revision, including the lines of untracked files.
+// The git repository is the one of the current directory.
+func (r *Revive) NewFromRev(rev string) error {
+	patch, err := git("diff", "--relative", "--no-color", "--no-ext-diff", "--src-prefix=a/", "--dst-prefix=b/", "-U0", rev, "--")
+	if err != nil {
+		return fmt.Errorf("cannot get the changes since %s: %w", rev, err)
+	}
+	changes, err := diff.Parse(patch)
+	if err != nil {
+		return fmt.Errorf("cannot parse the changes since %s: %w", rev, err)
+	}
+
+	untracked, err := git("ls-files", "--others", "--exclude-standard", "-z")
+	if err != nil {
+		return fmt.Errorf("cannot list the untracked files: %w", err)
+	}
+	for _, file := range strings.Split(string(untracked), "\x00") {
+		if file == "" {
+			continue
+		}
+		content, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		changes.AddFile(file, bytes.Count(content, []byte("\n"))+1)
+	}
+
+	r.changes = changes
+	return nil
+}
+
+// NewFromPatch makes Lint report only the failures on lines added or changed
+// by the given unified diff. File paths in the patch must be relative to the
+// current directory; the "b/" prefix of git diffs is ignored.
+func (r *Revive) NewFromPatch(patch []byte) error {
+	changes, err := diff.Parse(patch)
+	if err != nil {
+		return fmt.Errorf("cannot parse the patch: %w", err)
+	}
+	r.changes = changes
+	return nil
+}
+
+func git(args ...string) ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command("git", args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%w: %s", err, msg)
+		}
+		return nil, err
+	}
+	return out, nil
+}
+
+// isNew returns true if the failure is on a changed line, or reports an error.
+func isNew(changes diff.Changes, failure lint.Failure) bool {
+	if failure.Category == lint.FailureCategoryError {
+		return true
+	}
+	return changes.Contains(failure.GetFilename(), failure.Position.Start.Line)
+}
+
+// filterNew forwards to the returned channel the failures on changed lines.
+func filterNew(ctx context.Context, changes diff.Changes, failures <-chan lint.Failure) <-chan lint.Failure {
+	result := make(chan lint.Failure)
+	go func() {
+		defer close(result)
+		for failure := range failures {
+			if !isNew(changes, failure) {
+				continue
+			}
+			select {
+			case result <- failure:
+			case <-ctx.Done():
+			}
+		}
+	}()
+	return result
+}
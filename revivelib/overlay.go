@@ -0,0 +1,95 @@
+package revivelib
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mgechev/revive/lint"
+	"github.com/pkg/errors"
+)
+
+// SetOverlay makes Lint read the given content instead of the one of the file
+// at path, e.g. the unsaved buffer of an editor. A nil content removes the overlay.
+func (r *Revive) SetOverlay(path string, content []byte) {
+	r.overlayMu.Lock()
+	defer r.overlayMu.Unlock()
+	path = filepath.Clean(path)
+	if content == nil {
+		delete(r.overlay, path)
+		return
+	}
+	if r.overlay == nil {
+		r.overlay = map[string][]byte{}
+	}
+	r.overlay[path] = content
+}
+
+// readFile returns the content of the file, from the overlay if set.
+func (r *Revive) readFile(file string) ([]byte, error) {
+	r.overlayMu.RLock()
+	content, ok := r.overlay[filepath.Clean(file)]
+	r.overlayMu.RUnlock()
+	if ok {
+		return content, nil
+	}
+
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading file "+file)
+	}
+	return content, nil
+}
+
+// LintBuffer lints the file at filename, whose content is given instead of being read from disk,
+// and reports only its failures. The other files of its package, i.e. the Go files of its directory,
+// are read from disk so that package level information, such as type information, is available.
+// The file does not need to exist on disk. The content is kept as the overlay of the file, see SetOverlay.
+func (r *Revive) LintBuffer(ctx context.Context, filename string, content []byte) (<-chan lint.Failure, error) {
+	filename = filepath.Clean(filename)
+	r.SetOverlay(filename, content)
+
+	failures, err := r.LintPackageOf(ctx, filename)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(chan lint.Failure)
+	go func() {
+		defer close(result)
+		for failure := range failures {
+			if filepath.Clean(failure.GetFilename()) != filename {
+				continue
+			}
+			select {
+			case result <- failure:
+			case <-ctx.Done():
+			}
+		}
+	}()
+	return result, nil
+}
+
+// LintPackageOf lints the package of the file at filename, i.e. the Go files of its directory
+// and filename itself, that does not need to exist on disk, reading their overlay if set.
+func (r *Revive) LintPackageOf(ctx context.Context, filename string) (<-chan lint.Failure, error) {
+	filename = filepath.Clean(filename)
+	dir := filepath.Dir(filename)
+	entries, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, errors.Wrap(err, "linting - reading the directory of "+filename)
+	}
+	files := []string{filename}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") {
+			continue
+		}
+		if path := filepath.Join(dir, name); path != filename {
+			files = append(files, path)
+		}
+	}
+
+	return r.LintPackages(ctx, [][]string{files})
+}
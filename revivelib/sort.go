@@ -0,0 +1,137 @@
+package revivelib
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mgechev/revive/lint"
+)
+
+// SortOrder is the order in which Format passes the failures to the formatter.
+type SortOrder string
+
+const (
+	// SortDefault sorts by position, except for streaming formatters
+	// which get the failures as soon as they are found.
+	SortDefault SortOrder = ""
+	// SortPosition sorts by filename, line, column and rule.
+	SortPosition SortOrder = "position"
+	// SortSeverity sorts the errors before the warnings, then by position.
+	SortSeverity SortOrder = "severity"
+	// SortRule sorts by rule, then by position.
+	SortRule SortOrder = "rule"
+	// SortNone passes the failures as soon as they are found, in no particular order.
+	SortNone SortOrder = "none"
+)
+
+var sortOrders = []SortOrder{SortPosition, SortSeverity, SortRule, SortNone}
+
+// ParseSortOrder returns the sort order of the given name,
+// one of "position", "severity", "rule" and "none".
+func ParseSortOrder(name string) (SortOrder, error) {
+	names := make([]string, len(sortOrders))
+	for i, order := range sortOrders {
+		if string(order) == name {
+			return order, nil
+		}
+		names[i] = string(order)
+	}
+	return "", fmt.Errorf("unknown sort order %q, expected one of %s", name, strings.Join(names, ", "))
+}
+
+// SetSortOrder sets the order in which Format passes the failures to the formatter.
+func (r *Revive) SetSortOrder(order SortOrder) {
+	r.sortOrder = order
+}
+
+// sortOrderFor returns the effective sort order for the given formatter.
+func (r *Revive) sortOrderFor(formatter lint.Formatter) SortOrder {
+	if r.sortOrder != SortDefault {
+		return r.sortOrder
+	}
+	if f, ok := formatter.(lint.StreamingFormatter); ok && f.Streaming() {
+		return SortNone
+	}
+	return SortPosition
+}
+
+// sortFailures sorts the given failures in the given order.
+func sortFailures(failures []lint.Failure, order SortOrder, conf *lint.Config) {
+	sort.SliceStable(failures, func(i, j int) bool {
+		a, b := failures[i], failures[j]
+		switch order {
+		case SortSeverity:
+			if aErr, bErr := isError(conf, a), isError(conf, b); aErr != bErr {
+				return aErr
+			}
+		case SortRule:
+			if a.RuleName != b.RuleName {
+				return a.RuleName < b.RuleName
+			}
+		}
+		return lessByPosition(a, b)
+	})
+}
+
+// lessByPosition orders failures by filename, line, column, rule and message.
+func lessByPosition(a, b lint.Failure) bool {
+	pa, pb := a.Position.Start, b.Position.Start
+	switch {
+	case pa.Filename != pb.Filename:
+		return pa.Filename < pb.Filename
+	case pa.Line != pb.Line:
+		return pa.Line < pb.Line
+	case pa.Column != pb.Column:
+		return pa.Column < pb.Column
+	case a.RuleName != b.RuleName:
+		return a.RuleName < b.RuleName
+	default:
+		return a.Failure < b.Failure
+	}
+}
+
+// Severity returns the severity of the failure according to the configuration.
+func (r *Revive) Severity(failure lint.Failure) lint.Severity {
+	if isError(r.configOf(failure), failure) {
+		return lint.SeverityError
+	}
+	return lint.SeverityWarning
+}
+
+// Confidence returns the minimum confidence for the failure to be reported by Format,
+// according to the configuration it was reported with.
+func (r *Revive) Confidence(failure lint.Failure) float64 {
+	return r.configOf(failure).Confidence
+}
+
+// configOf returns the configuration the failure was reported with, that of its package
+// with nested configurations, otherwise the configuration given to New.
+func (r *Revive) configOf(failure lint.Failure) *lint.Config {
+	if r.hierarchy == nil || failure.GetFilename() == "" {
+		return r.config
+	}
+	_, conf, err := r.hierarchy.Resolve(filepath.Dir(failure.GetFilename()))
+	if err != nil {
+		return r.config
+	}
+	return &conf
+}
+
+// isError returns true if the failure has the error severity.
+func isError(conf *lint.Config, failure lint.Failure) bool {
+	if failure.Category == lint.FailureCategoryError {
+		return true
+	}
+	if failure.Severity != "" {
+		return failure.Severity == lint.SeverityError
+	}
+	if c, ok := conf.Rules[failure.RuleName]; ok && c.Severity == lint.SeverityError {
+		return true
+	}
+	if c, ok := conf.Directives[failure.RuleName]; ok && c.Severity == lint.SeverityError {
+		return true
+	}
+	return false
+}
@@ -1,12 +1,12 @@
 package rule
 
 import (
+	"errors"
 	"fmt"
 	"go/ast"
 	"regexp"
 	"strconv"
 	"strings"
-	"sync"
 
 	"github.com/mgechev/revive/lint"
 )
@@ -33,15 +33,16 @@ func (wl allowList) add(kind, list string) {
 
 // AddConstantRule lints unused params in functions.
 type AddConstantRule struct {
+	configureOnce configureOnce
+
 	allowList       allowList
 	ignoreFunctions []*regexp.Regexp
 	strLitLimit     int
-	sync.Mutex
 }
 
 // Apply applies the rule to given file.
 func (r *AddConstantRule) Apply(file *lint.File, arguments lint.Arguments) []lint.Failure {
-	r.configure(arguments)
+	r.configureOnce.ensure(func() error { return r.configure(arguments) })
 
 	var failures []lint.Failure
 
@@ -68,6 +69,28 @@ func (*AddConstantRule) Name() string {
 	return "add-constant"
 }
 
+// Metadata returns the description of the rule.
+func (*AddConstantRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports magic numbers and string literals repeated more than a maximum number of times, that could be named constants.",
+		Rationale:   "A named constant explains the meaning of the value, and changing it changes all its uses.",
+		Category:    "style",
+		Arguments: []lint.RuleArgument{
+			{Name: "maxLitCount", Type: "string", Description: "maximum number of occurrences of a string literal, 2 by default"},
+			{Name: "allowStrs", Type: "string", Description: "comma-separated list of allowed string literals"},
+			{Name: "allowInts", Type: "string", Description: "comma-separated list of allowed integers, 0,1 by default"},
+			{Name: "allowFloats", Type: "string", Description: "comma-separated list of allowed floats, 0.0,1.0 by default"},
+			{Name: "ignoreFuncs", Type: "string", Description: "comma-separated list of regular expressions of the functions whose arguments are ignored"},
+		},
+		Examples: []lint.RuleExample{{
+			Bad: `timeout := 30 * time.Second`,
+			Good: `const defaultTimeout = 30 * time.Second
+
+timeout := defaultTimeout`,
+		}},
+	}
+}
+
 type lintAddConstantRule struct {
 	onFailure       func(lint.Failure)
 	strLits         map[string]int
@@ -197,70 +220,74 @@ func (w *lintAddConstantRule) isStructTag(n *ast.BasicLit) bool {
 	return ok
 }
 
-func (r *AddConstantRule) configure(arguments lint.Arguments) {
-	r.Lock()
-	defer r.Unlock()
+// Configure validates the arguments of the rule and configures it.
+func (r *AddConstantRule) Configure(arguments lint.Arguments) error {
+	return r.configureOnce.do(func() error { return r.configure(arguments) })
+}
 
-	if r.allowList == nil {
-		r.strLitLimit = defaultStrLitLimit
-		r.allowList = newAllowList()
-		if len(arguments) > 0 {
-			args, ok := arguments[0].(map[string]any)
+func (r *AddConstantRule) configure(arguments lint.Arguments) error {
+	r.strLitLimit = defaultStrLitLimit
+	r.allowList = newAllowList()
+	r.ignoreFunctions = nil
+	if len(arguments) == 0 {
+		return nil
+	}
+
+	args, ok := arguments[0].(map[string]any)
+	if !ok {
+		return fmt.Errorf("invalid argument to the add-constant rule. Expecting a k,v map, got %T", arguments[0])
+	}
+	for k, v := range args {
+		kind := ""
+		switch k {
+		case "allowFloats":
+			kind = kindFLOAT
+			fallthrough
+		case "allowInts":
+			if kind == "" {
+				kind = kindINT
+			}
+			fallthrough
+		case "allowStrs":
+			if kind == "" {
+				kind = kindSTRING
+			}
+			list, ok := v.(string)
 			if !ok {
-				panic(fmt.Sprintf("Invalid argument to the add-constant rule. Expecting a k,v map, got %T", arguments[0]))
+				return fmt.Errorf("invalid argument to the add-constant rule, string expected. Got '%v' (%T)", v, v)
 			}
-			for k, v := range args {
-				kind := ""
-				switch k {
-				case "allowFloats":
-					kind = kindFLOAT
-					fallthrough
-				case "allowInts":
-					if kind == "" {
-						kind = kindINT
-					}
-					fallthrough
-				case "allowStrs":
-					if kind == "" {
-						kind = kindSTRING
-					}
-					list, ok := v.(string)
-					if !ok {
-						panic(fmt.Sprintf("Invalid argument to the add-constant rule, string expected. Got '%v' (%T)", v, v))
-					}
-					r.allowList.add(kind, list)
-				case "maxLitCount":
-					sl, ok := v.(string)
-					if !ok {
-						panic(fmt.Sprintf("Invalid argument to the add-constant rule, expecting string representation of an integer. Got '%v' (%T)", v, v))
-					}
-
-					limit, err := strconv.Atoi(sl)
-					if err != nil {
-						panic(fmt.Sprintf("Invalid argument to the add-constant rule, expecting string representation of an integer. Got '%v'", v))
-					}
-					r.strLitLimit = limit
-				case "ignoreFuncs":
-					excludes, ok := v.(string)
-					if !ok {
-						panic(fmt.Sprintf("Invalid argument to the ignoreFuncs parameter of add-constant rule, string expected. Got '%v' (%T)", v, v))
-					}
-
-					for _, exclude := range strings.Split(excludes, ",") {
-						exclude = strings.Trim(exclude, " ")
-						if exclude == "" {
-							panic("Invalid argument to the ignoreFuncs parameter of add-constant rule, expected regular expression must not be empty.")
-						}
-
-						exp, err := regexp.Compile(exclude)
-						if err != nil {
-							panic(fmt.Sprintf("Invalid argument to the ignoreFuncs parameter of add-constant rule: regexp %q does not compile: %v", exclude, err))
-						}
-
-						r.ignoreFunctions = append(r.ignoreFunctions, exp)
-					}
+			r.allowList.add(kind, list)
+		case "maxLitCount":
+			sl, ok := v.(string)
+			if !ok {
+				return fmt.Errorf("invalid argument to the add-constant rule, expecting string representation of an integer. Got '%v' (%T)", v, v)
+			}
+
+			limit, err := strconv.Atoi(sl)
+			if err != nil {
+				return fmt.Errorf("invalid argument to the add-constant rule, expecting string representation of an integer. Got '%v'", v)
+			}
+			r.strLitLimit = limit
+		case "ignoreFuncs":
+			excludes, ok := v.(string)
+			if !ok {
+				return fmt.Errorf("invalid argument to the ignoreFuncs parameter of add-constant rule, string expected. Got '%v' (%T)", v, v)
+			}
+
+			for _, exclude := range strings.Split(excludes, ",") {
+				exclude = strings.Trim(exclude, " ")
+				if exclude == "" {
+					return errors.New("invalid argument to the ignoreFuncs parameter of add-constant rule, expected regular expression must not be empty")
 				}
+
+				exp, err := regexp.Compile(exclude)
+				if err != nil {
+					return fmt.Errorf("invalid argument to the ignoreFuncs parameter of add-constant rule: regexp %q does not compile: %w", exclude, err)
+				}
+
+				r.ignoreFunctions = append(r.ignoreFunctions, exp)
 			}
 		}
 	}
+	return nil
 }
@@ -3,39 +3,41 @@ package rule
 import (
 	"fmt"
 	"go/ast"
-	"sync"
 
 	"github.com/mgechev/revive/lint"
 )
 
 // ArgumentsLimitRule lints given else constructs.
 type ArgumentsLimitRule struct {
+	configureOnce configureOnce
+
 	total int
-	sync.Mutex
 }
 
 const defaultArgumentsLimit = 8
 
-func (r *ArgumentsLimitRule) configure(arguments lint.Arguments) {
-	r.Lock()
-	defer r.Unlock()
-	if r.total == 0 {
-		if len(arguments) < 1 {
-			r.total = defaultArgumentsLimit
-			return
-		}
+// Configure validates the arguments of the rule and configures it.
+func (r *ArgumentsLimitRule) Configure(arguments lint.Arguments) error {
+	return r.configureOnce.do(func() error { return r.configure(arguments) })
+}
 
-		total, ok := arguments[0].(int64) // Alt. non panicking version
-		if !ok {
-			panic(`invalid value passed as argument number to the "argument-limit" rule`)
-		}
-		r.total = int(total)
+func (r *ArgumentsLimitRule) configure(arguments lint.Arguments) error {
+	if len(arguments) < 1 {
+		r.total = defaultArgumentsLimit
+		return nil
 	}
+
+	total, ok := arguments[0].(int64)
+	if !ok {
+		return fmt.Errorf(`invalid value passed as argument number to the "argument-limit" rule; need int64 but got %T`, arguments[0])
+	}
+	r.total = int(total)
+	return nil
 }
 
 // Apply applies the rule to given file.
 func (r *ArgumentsLimitRule) Apply(file *lint.File, arguments lint.Arguments) []lint.Failure {
-	r.configure(arguments)
+	r.configureOnce.ensure(func() error { return r.configure(arguments) })
 
 	var failures []lint.Failure
 	onFailure := func(failure lint.Failure) {
@@ -57,6 +59,22 @@ func (*ArgumentsLimitRule) Name() string {
 	return "argument-limit"
 }
 
+// Metadata returns the description of the rule.
+func (*ArgumentsLimitRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports functions with more parameters than a maximum.",
+		Rationale:   "A function with many parameters is hard to call and to understand, grouping them in a struct helps.",
+		Category:    "complexity",
+		Arguments: []lint.RuleArgument{
+			{Name: "max", Type: "integer", Description: "maximum number of parameters, 8 by default"},
+		},
+		Examples: []lint.RuleExample{{
+			Bad:  `func connect(host string, port int, user, password string, timeout, retries int, tls, verbose bool, proxy string) error`,
+			Good: `func connect(options ConnectOptions) error`,
+		}},
+	}
+}
+
 type lintArgsNum struct {
 	total     int
 	onFailure func(lint.Failure)
@@ -31,6 +31,19 @@ func (*AtomicRule) Name() string {
 	return "atomic"
 }
 
+// Metadata returns the description of the rule.
+func (*AtomicRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports assignments of the result of the functions of sync/atomic to the variable they update.",
+		Rationale:   "The assignment is not atomic: it races with the other updates of the variable.",
+		Category:    "bugs",
+		Examples: []lint.RuleExample{{
+			Bad:  `x = atomic.AddUint64(&x, 1)`,
+			Good: `atomic.AddUint64(&x, 1)`,
+		}},
+	}
+}
+
 type atomic struct {
 	pkgTypesInfo *types.Info
 	onFailure    func(lint.Failure)
@@ -4,31 +4,38 @@ import (
 	"fmt"
 	"go/ast"
 	"strings"
-	"sync"
 
 	"github.com/mgechev/revive/lint"
 )
 
 // BannedCharsRule checks if a file contains banned characters.
 type BannedCharsRule struct {
+	configureOnce configureOnce
+
 	bannedCharList []string
-	sync.Mutex
 }
 
 const bannedCharsRuleName = "banned-characters"
 
-func (r *BannedCharsRule) configure(arguments lint.Arguments) {
-	r.Lock()
-	defer r.Unlock()
-	if r.bannedCharList == nil && len(arguments) > 0 {
-		checkNumberOfArguments(1, arguments, bannedCharsRuleName)
-		r.bannedCharList = r.getBannedCharsList(arguments)
+// Configure validates the arguments of the rule and configures it.
+func (r *BannedCharsRule) Configure(arguments lint.Arguments) error {
+	return r.configureOnce.do(func() error { return r.configure(arguments) })
+}
+
+func (r *BannedCharsRule) configure(arguments lint.Arguments) error {
+	r.bannedCharList = nil
+	if len(arguments) == 0 {
+		return nil
 	}
+
+	var err error
+	r.bannedCharList, err = r.getBannedCharsList(arguments)
+	return err
 }
 
 // Apply applied the rule to the given file.
 func (r *BannedCharsRule) Apply(file *lint.File, arguments lint.Arguments) []lint.Failure {
-	r.configure(arguments)
+	r.configureOnce.ensure(func() error { return r.configure(arguments) })
 
 	var failures []lint.Failure
 	onFailure := func(failure lint.Failure) {
@@ -49,18 +56,34 @@ func (*BannedCharsRule) Name() string {
 	return bannedCharsRuleName
 }
 
+// Metadata returns the description of the rule.
+func (*BannedCharsRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports the names of functions, variables and constants containing banned characters.",
+		Rationale:   "Some characters, such as look-alikes of Latin letters, make the names hard to read or to type.",
+		Category:    "naming",
+		Arguments: []lint.RuleArgument{
+			{Name: "characters", Type: "list of strings", Description: "the banned characters"},
+		},
+		Examples: []lint.RuleExample{{
+			Bad:  `var Ωmega = 2`,
+			Good: `var omega = 2`,
+		}},
+	}
+}
+
 // getBannedCharsList converts arguments into the banned characters list
-func (r *BannedCharsRule) getBannedCharsList(args lint.Arguments) []string {
+func (r *BannedCharsRule) getBannedCharsList(args lint.Arguments) ([]string, error) {
 	var bannedChars []string
 	for _, char := range args {
 		charStr, ok := char.(string)
 		if !ok {
-			panic(fmt.Sprintf("Invalid argument for the %s rule: expecting a string, got %T", r.Name(), char))
+			return nil, fmt.Errorf("invalid argument for the %s rule: expecting a string, got %T", r.Name(), char)
 		}
 		bannedChars = append(bannedChars, charStr)
 	}
 
-	return bannedChars
+	return bannedChars, nil
 }
 
 type lintBannedCharsRule struct {
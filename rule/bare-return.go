@@ -27,6 +27,27 @@ func (*BareReturnRule) Name() string {
 	return "bare-return"
 }
 
+// Metadata returns the description of the rule.
+func (*BareReturnRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports bare returns, i.e. return statements without values in functions with named results.",
+		Rationale:   "The values returned by a bare return are not visible at the return statement.",
+		Category:    "style",
+		Examples: []lint.RuleExample{{
+			Bad: `func split(sum int) (x, y int) {
+	x = sum * 4 / 9
+	y = sum - x
+	return
+}`,
+			Good: `func split(sum int) (x, y int) {
+	x = sum * 4 / 9
+	y = sum - x
+	return x, y
+}`,
+		}},
+	}
+}
+
 type lintBareReturnRule struct {
 	onFailure func(lint.Failure)
 }
@@ -15,6 +15,23 @@ func (*BlankImportsRule) Name() string {
 	return "blank-imports"
 }
 
+// Metadata returns the description of the rule.
+func (*BlankImportsRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description:    "Reports blank imports outside of main and test packages without a comment justifying them.",
+		Rationale:      "A blank import only runs the side effects of the imported package, such as registering a driver: it belongs to the main package, or must explain why it is needed.",
+		Category:       "imports",
+		DefaultEnabled: true,
+		Examples: []lint.RuleExample{{
+			Bad: "import _ \"github.com/lib/pq\"",
+			Good: `import (
+	// registers the postgres driver of database/sql
+	_ "github.com/lib/pq"
+)`,
+		}},
+	}
+}
+
 // Apply applies the rule to given file.
 func (r *BlankImportsRule) Apply(file *lint.File, _ lint.Arguments) []lint.Failure {
 	if file.Pkg.IsMain() || file.IsTest() {
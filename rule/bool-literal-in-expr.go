@@ -30,6 +30,19 @@ func (*BoolLiteralRule) Name() string {
 	return "bool-literal-in-expr"
 }
 
+// Metadata returns the description of the rule.
+func (*BoolLiteralRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports boolean literals in logic expressions, such as comparisons with true or false.",
+		Rationale:   "The expression without the literal is shorter and easier to read.",
+		Category:    "logic",
+		Examples: []lint.RuleExample{{
+			Bad:  `if enabled == true {`,
+			Good: `if enabled {`,
+		}},
+	}
+}
+
 type lintBoolLiteral struct {
 	file      *ast.File
 	onFailure func(lint.Failure)
@@ -31,6 +31,20 @@ func (*CallToGCRule) Name() string {
 	return "call-to-gc"
 }
 
+// Metadata returns the description of the rule.
+func (*CallToGCRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports explicit calls to the garbage collector.",
+		Rationale:   "Except in benchmarks, forcing a garbage collection is rarely needed: the garbage collector is tuned with the GOGC and GOMEMLIMIT environment variables.",
+		Category:    "bugs",
+		Examples: []lint.RuleExample{{
+			Bad: `process(data)
+runtime.GC()`,
+			Good: `process(data)`,
+		}},
+	}
+}
+
 type lintCallToGC struct {
 	onFailure             func(lint.Failure)
 	gcTriggeringFunctions map[string]map[string]bool
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"go/ast"
 	"go/token"
-	"sync"
 
 	"github.com/mgechev/revive/lint"
 	"golang.org/x/tools/go/ast/astutil"
@@ -12,33 +11,35 @@ import (
 
 // CognitiveComplexityRule lints given else constructs.
 type CognitiveComplexityRule struct {
+	configureOnce configureOnce
+
 	maxComplexity int
-	sync.Mutex
 }
 
 const defaultMaxCognitiveComplexity = 7
 
-func (r *CognitiveComplexityRule) configure(arguments lint.Arguments) {
-	r.Lock()
-	defer r.Unlock()
-	if r.maxComplexity == 0 {
+// Configure validates the arguments of the rule and configures it.
+func (r *CognitiveComplexityRule) Configure(arguments lint.Arguments) error {
+	return r.configureOnce.do(func() error { return r.configure(arguments) })
+}
 
-		if len(arguments) < 1 {
-			r.maxComplexity = defaultMaxCognitiveComplexity
-			return
-		}
+func (r *CognitiveComplexityRule) configure(arguments lint.Arguments) error {
+	if len(arguments) < 1 {
+		r.maxComplexity = defaultMaxCognitiveComplexity
+		return nil
+	}
 
-		complexity, ok := arguments[0].(int64)
-		if !ok {
-			panic(fmt.Sprintf("invalid argument type for cognitive-complexity, expected int64, got %T", arguments[0]))
-		}
-		r.maxComplexity = int(complexity)
+	complexity, ok := arguments[0].(int64)
+	if !ok {
+		return fmt.Errorf("invalid argument type for cognitive-complexity, expected int64, got %T", arguments[0])
 	}
+	r.maxComplexity = int(complexity)
+	return nil
 }
 
 // Apply applies the rule to given file.
 func (r *CognitiveComplexityRule) Apply(file *lint.File, arguments lint.Arguments) []lint.Failure {
-	r.configure(arguments)
+	r.configureOnce.ensure(func() error { return r.configure(arguments) })
 
 	var failures []lint.Failure
 
@@ -60,6 +61,18 @@ func (*CognitiveComplexityRule) Name() string {
 	return "cognitive-complexity"
 }
 
+// Metadata returns the description of the rule.
+func (*CognitiveComplexityRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports functions whose cognitive complexity, a measure of how hard code is to understand, exceeds a maximum.",
+		Rationale:   "Complex functions are hard to read and to maintain, splitting them makes them easier to understand.",
+		Category:    "complexity",
+		Arguments: []lint.RuleArgument{
+			{Name: "max", Type: "integer", Description: "maximum cognitive complexity of a function, 7 by default"},
+		},
+	}
+}
+
 type cognitiveComplexityLinter struct {
 	file          *lint.File
 	maxComplexity int
@@ -3,7 +3,6 @@ package rule
 import (
 	"fmt"
 	"strings"
-	"sync"
 
 	"github.com/mgechev/revive/lint"
 )
@@ -11,29 +10,31 @@ import (
 // CommentSpacingsRule check the whether there is a space between
 // the comment symbol( // ) and the start of the comment text
 type CommentSpacingsRule struct {
+	configureOnce configureOnce
+
 	allowList []string
-	sync.Mutex
 }
 
-func (r *CommentSpacingsRule) configure(arguments lint.Arguments) {
-	r.Lock()
-	defer r.Unlock()
+// Configure validates the arguments of the rule and configures it.
+func (r *CommentSpacingsRule) Configure(arguments lint.Arguments) error {
+	return r.configureOnce.do(func() error { return r.configure(arguments) })
+}
 
-	if r.allowList == nil {
-		r.allowList = []string{}
-		for _, arg := range arguments {
-			allow, ok := arg.(string) // Alt. non panicking version
-			if !ok {
-				panic(fmt.Sprintf("invalid argument %v for %s; expected string but got %T", arg, r.Name(), arg))
-			}
-			r.allowList = append(r.allowList, `//`+allow)
+func (r *CommentSpacingsRule) configure(arguments lint.Arguments) error {
+	r.allowList = []string{}
+	for _, arg := range arguments {
+		allow, ok := arg.(string)
+		if !ok {
+			return fmt.Errorf("invalid argument %v for %s; expected string but got %T", arg, r.Name(), arg)
 		}
+		r.allowList = append(r.allowList, `//`+allow)
 	}
+	return nil
 }
 
 // Apply the rule.
-func (r *CommentSpacingsRule) Apply(file *lint.File, args lint.Arguments) []lint.Failure {
-	r.configure(args)
+func (r *CommentSpacingsRule) Apply(file *lint.File, arguments lint.Arguments) []lint.Failure {
+	r.configureOnce.ensure(func() error { return r.configure(arguments) })
 
 	var failures []lint.Failure
 
@@ -75,6 +76,22 @@ func (*CommentSpacingsRule) Name() string {
 	return "comment-spacings"
 }
 
+// Metadata returns the description of the rule.
+func (*CommentSpacingsRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports comments without a space after the comment delimiter //.",
+		Rationale:   "A space after the delimiter is the convention of Go comments, only directives such as //go:generate omit it.",
+		Category:    "style",
+		Arguments: []lint.RuleArgument{
+			{Name: "exceptions", Type: "list of strings", Description: "prefixes of the comments allowed without a space, e.g. \"mypragma:\""},
+		},
+		Examples: []lint.RuleExample{{
+			Bad:  `//Parse parses the input.`,
+			Good: `// Parse parses the input.`,
+		}},
+	}
+}
+
 func (r *CommentSpacingsRule) isAllowed(line string) bool {
 	for _, allow := range r.allowList {
 		if strings.HasPrefix(line, allow) {
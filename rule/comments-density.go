@@ -4,45 +4,41 @@ import (
 	"fmt"
 	"go/ast"
 	"strings"
-	"sync"
 
 	"github.com/mgechev/revive/lint"
 )
 
 // CommentsDensityRule lints given else constructs.
 type CommentsDensityRule struct {
+	configureOnce configureOnce
+
 	minimumCommentsDensity int64
-	configured             bool
-	sync.Mutex
 }
 
 const defaultMinimumCommentsPercentage = 0
 
-func (r *CommentsDensityRule) configure(arguments lint.Arguments) {
-	r.Lock()
-	defer r.Unlock()
-
-	if r.configured {
-		return
-	}
-
-	r.configured = true
+// Configure validates the arguments of the rule and configures it.
+func (r *CommentsDensityRule) Configure(arguments lint.Arguments) error {
+	return r.configureOnce.do(func() error { return r.configure(arguments) })
+}
 
+func (r *CommentsDensityRule) configure(arguments lint.Arguments) error {
 	if len(arguments) < 1 {
 		r.minimumCommentsDensity = defaultMinimumCommentsPercentage
-		return
+		return nil
 	}
 
 	var ok bool
 	r.minimumCommentsDensity, ok = arguments[0].(int64)
 	if !ok {
-		panic(fmt.Sprintf("invalid argument for %q rule: argument should be an int, got %T", r.Name(), arguments[0]))
+		return fmt.Errorf("invalid argument for %q rule: argument should be an int, got %T", r.Name(), arguments[0])
 	}
+	return nil
 }
 
 // Apply applies the rule to given file.
 func (r *CommentsDensityRule) Apply(file *lint.File, arguments lint.Arguments) []lint.Failure {
-	r.configure(arguments)
+	r.configureOnce.ensure(func() error { return r.configure(arguments) })
 
 	commentsLines := countDocLines(file.AST.Comments)
 	statementsCount := countStatements(file.AST)
@@ -66,6 +62,18 @@ func (*CommentsDensityRule) Name() string {
 	return "comments-density"
 }
 
+// Metadata returns the description of the rule.
+func (*CommentsDensityRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports files whose percentage of comment lines, among the lines of code and of comments, is below a minimum.",
+		Rationale:   "Comments explain what the code cannot say by itself.",
+		Category:    "comments",
+		Arguments: []lint.RuleArgument{
+			{Name: "min", Type: "integer", Description: "minimum percentage of comment lines, 0 by default"},
+		},
+	}
+}
+
 // countStatements counts the number of program statements in the given AST.
 func countStatements(node ast.Node) int {
 	counter := 0
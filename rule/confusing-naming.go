@@ -3,8 +3,8 @@ package rule
 import (
 	"fmt"
 	"go/ast"
+	"sort"
 	"strings"
-	"sync"
 
 	"github.com/mgechev/revive/lint"
 )
@@ -14,52 +14,47 @@ type referenceMethod struct {
 	id       *ast.Ident
 }
 
-type pkgMethods struct {
-	pkg     *lint.Package
-	methods map[string]map[string]*referenceMethod
-	mu      *sync.Mutex
-}
+// pkgMethods maps holder (struct) names to the normalized names of their methods
+type pkgMethods map[string]map[string]*referenceMethod
 
-type packages struct {
-	pkgs []pkgMethods
-	mu   sync.Mutex
-}
+// ConfusingNamingRule lints method names that differ only by capitalization
+type ConfusingNamingRule struct{}
 
-func (ps *packages) methodNames(lp *lint.Package) pkgMethods {
-	ps.mu.Lock()
-	defer ps.mu.Unlock()
+// Apply applies the rule to given file.
+// Only the methods and functions declared in the file are taken into account,
+// ApplyPackage checks them across the whole package.
+func (*ConfusingNamingRule) Apply(file *lint.File, _ lint.Arguments) []lint.Failure {
+	return applyConfusingNaming([]*lint.File{file})
+}
 
-	for _, pkg := range ps.pkgs {
-		if pkg.pkg == lp {
-			return pkg
-		}
+// ApplyPackage applies the rule to the given package.
+func (*ConfusingNamingRule) ApplyPackage(pkg *lint.Package, _ lint.Arguments) []lint.Failure {
+	files := make([]*lint.File, 0, len(pkg.Files()))
+	for _, file := range pkg.Files() {
+		files = append(files, file)
 	}
+	// sort files to always report the same declaration of a pair
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Name < files[j].Name
+	})
 
-	pkgm := pkgMethods{pkg: lp, methods: make(map[string]map[string]*referenceMethod), mu: &sync.Mutex{}}
-	ps.pkgs = append(ps.pkgs, pkgm)
-
-	return pkgm
+	return applyConfusingNaming(files)
 }
 
-var allPkgs = packages{pkgs: make([]pkgMethods, 1)}
-
-// ConfusingNamingRule lints method names that differ only by capitalization
-type ConfusingNamingRule struct{}
-
-// Apply applies the rule to given file.
-func (*ConfusingNamingRule) Apply(file *lint.File, _ lint.Arguments) []lint.Failure {
+func applyConfusingNaming(files []*lint.File) []lint.Failure {
 	var failures []lint.Failure
-	fileAst := file.AST
-	pkgm := allPkgs.methodNames(file.Pkg)
-	walker := lintConfusingNames{
-		fileName: file.Name,
-		pkgm:     pkgm,
-		onFailure: func(failure lint.Failure) {
-			failures = append(failures, failure)
-		},
-	}
+	pkgm := pkgMethods{}
+	for _, file := range files {
+		walker := lintConfusingNames{
+			fileName: file.Name,
+			pkgm:     pkgm,
+			onFailure: func(failure lint.Failure) {
+				failures = append(failures, failure)
+			},
+		}
 
-	ast.Walk(&walker, fileAst)
+		ast.Walk(&walker, file.AST)
+	}
 
 	return failures
 }
@@ -69,6 +64,21 @@ func (*ConfusingNamingRule) Name() string {
 	return "confusing-naming"
 }
 
+// Metadata returns the description of the rule.
+func (*ConfusingNamingRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports methods and functions whose names differ only by capitalization.",
+		Rationale:   "Names differing only by capitalization are easily confused.",
+		Category:    "naming",
+		Examples: []lint.RuleExample{{
+			Bad: `func (s *Server) Start() error
+func (s *Server) start() error`,
+			Good: `func (s *Server) Start() error
+func (s *Server) listen() error`,
+		}},
+	}
+}
+
 // checkMethodName checks if a given method/function name is similar (just case differences) to other method/function of the same struct/file.
 func checkMethodName(holder string, id *ast.Ident, w *lintConfusingNames) {
 	if id.Name == "init" && holder == defaultStructName {
@@ -79,12 +89,9 @@ func checkMethodName(holder string, id *ast.Ident, w *lintConfusingNames) {
 	pkgm := w.pkgm
 	name := strings.ToUpper(id.Name)
 
-	pkgm.mu.Lock()
-	defer pkgm.mu.Unlock()
-
-	if pkgm.methods[holder] != nil {
-		if pkgm.methods[holder][name] != nil {
-			refMethod := pkgm.methods[holder][name]
+	if pkgm[holder] != nil {
+		if pkgm[holder][name] != nil {
+			refMethod := pkgm[holder][name]
 			// confusing names
 			var kind string
 			if holder == defaultStructName {
@@ -108,14 +115,11 @@ func checkMethodName(holder string, id *ast.Ident, w *lintConfusingNames) {
 			return
 		}
 	} else {
-		pkgm.methods[holder] = make(map[string]*referenceMethod, 1)
+		pkgm[holder] = make(map[string]*referenceMethod, 1)
 	}
 
 	// update the block list
-	if pkgm.methods[holder] == nil {
-		println("no entry for '", holder, "'")
-	}
-	pkgm.methods[holder][name] = &referenceMethod{fileName: w.fileName, id: id}
+	pkgm[holder][name] = &referenceMethod{fileName: w.fileName, id: id}
 }
 
 type lintConfusingNames struct {
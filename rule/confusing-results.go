@@ -30,6 +30,19 @@ func (*ConfusingResultsRule) Name() string {
 	return "confusing-results"
 }
 
+// Metadata returns the description of the rule.
+func (*ConfusingResultsRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports functions returning several unnamed results of the same type.",
+		Rationale:   "The results are easily swapped by the callers, naming them documents their meaning.",
+		Category:    "naming",
+		Examples: []lint.RuleExample{{
+			Bad:  `func position() (int, int)`,
+			Good: `func position() (line, column int)`,
+		}},
+	}
+}
+
 type lintConfusingResults struct {
 	onFailure func(lint.Failure)
 }
@@ -29,6 +29,19 @@ func (*ConstantLogicalExprRule) Name() string {
 	return "constant-logical-expr"
 }
 
+// Metadata returns the description of the rule.
+func (*ConstantLogicalExprRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports logical expressions always evaluating to the same value, such as comparisons of an expression with itself.",
+		Rationale:   "Such an expression is useless, or the sign of a typo.",
+		Category:    "logic",
+		Examples: []lint.RuleExample{{
+			Bad:  `if a == a {`,
+			Good: `if a == b {`,
+		}},
+	}
+}
+
 type lintConstantLogicalExpr struct {
 	file      *ast.File
 	onFailure func(lint.Failure)
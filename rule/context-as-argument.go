@@ -4,34 +4,39 @@ import (
 	"fmt"
 	"go/ast"
 	"strings"
-	"sync"
 
 	"github.com/mgechev/revive/lint"
 )
 
 // ContextAsArgumentRule lints given else constructs.
 type ContextAsArgumentRule struct {
+	configureOnce configureOnce
+
 	allowTypesLUT map[string]struct{}
-	sync.Mutex
+}
+
+// Configure validates the arguments of the rule and configures it.
+func (r *ContextAsArgumentRule) Configure(arguments lint.Arguments) error {
+	return r.configureOnce.do(func() error { return r.configure(arguments) })
+}
+
+func (r *ContextAsArgumentRule) configure(arguments lint.Arguments) error {
+	var err error
+	r.allowTypesLUT, err = getAllowTypesFromArguments(arguments)
+	return err
 }
 
 // Apply applies the rule to given file.
-func (r *ContextAsArgumentRule) Apply(file *lint.File, args lint.Arguments) []lint.Failure {
-	r.Lock()
-	if r.allowTypesLUT == nil {
-		r.allowTypesLUT = getAllowTypesFromArguments(args)
-	}
-	r.Unlock()
+func (r *ContextAsArgumentRule) Apply(file *lint.File, arguments lint.Arguments) []lint.Failure {
+	r.configureOnce.ensure(func() error { return r.configure(arguments) })
 
 	var failures []lint.Failure
-	r.Lock()
 	walker := lintContextArguments{
 		allowTypesLUT: r.allowTypesLUT,
 		onFailure: func(failure lint.Failure) {
 			failures = append(failures, failure)
 		},
 	}
-	r.Unlock()
 
 	ast.Walk(walker, file.AST)
 
@@ -43,6 +48,23 @@ func (*ContextAsArgumentRule) Name() string {
 	return "context-as-argument"
 }
 
+// Metadata returns the description of the rule.
+func (*ContextAsArgumentRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description:    "Reports functions whose context.Context parameter is not the first one.",
+		Rationale:      "By convention, the context is the first parameter of a function.",
+		Category:       "api",
+		DefaultEnabled: true,
+		Arguments: []lint.RuleArgument{
+			{Name: "allowTypesBefore", Type: "string", Description: "comma-separated list of the types allowed before the context, e.g. \"*testing.T\", given as [{ allowTypesBefore = \"...\" }]"},
+		},
+		Examples: []lint.RuleExample{{
+			Bad:  "func Fetch(url string, ctx context.Context) error",
+			Good: "func Fetch(ctx context.Context, url string) error",
+		}},
+	}
+}
+
 type lintContextArguments struct {
 	allowTypesLUT map[string]struct{}
 	onFailure     func(lint.Failure)
@@ -79,23 +101,23 @@ func (w lintContextArguments) Visit(n ast.Node) ast.Visitor {
 	return nil // avoid visiting the function body
 }
 
-func getAllowTypesFromArguments(args lint.Arguments) map[string]struct{} {
+func getAllowTypesFromArguments(args lint.Arguments) (map[string]struct{}, error) {
 	allowTypesBefore := []string{}
 	if len(args) >= 1 {
 		argKV, ok := args[0].(map[string]any)
 		if !ok {
-			panic(fmt.Sprintf("Invalid argument to the context-as-argument rule. Expecting a k,v map, got %T", args[0]))
+			return nil, fmt.Errorf("invalid argument to the context-as-argument rule. Expecting a k,v map, got %T", args[0])
 		}
 		for k, v := range argKV {
 			switch k {
 			case "allowTypesBefore":
 				typesBefore, ok := v.(string)
 				if !ok {
-					panic(fmt.Sprintf("Invalid argument to the context-as-argument.allowTypesBefore rule. Expecting a string, got %T", v))
+					return nil, fmt.Errorf("invalid argument to the context-as-argument.allowTypesBefore rule. Expecting a string, got %T", v)
 				}
 				allowTypesBefore = append(allowTypesBefore, strings.Split(typesBefore, ",")...)
 			default:
-				panic(fmt.Sprintf("Invalid argument to the context-as-argument rule. Unrecognized key %s", k))
+				return nil, fmt.Errorf("invalid argument to the context-as-argument rule. Unrecognized key %s", k)
 			}
 		}
 	}
@@ -106,5 +128,5 @@ func getAllowTypesFromArguments(args lint.Arguments) map[string]struct{} {
 	}
 
 	result["context.Context"] = struct{}{} // context.Context is always allowed before another context.Context
-	return result
+	return result, nil
 }
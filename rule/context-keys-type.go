@@ -35,6 +35,22 @@ func (*ContextKeysType) Name() string {
 	return "context-keys-type"
 }
 
+// Metadata returns the description of the rule.
+func (*ContextKeysType) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description:    "Reports context.WithValue calls using a key of a basic type.",
+		Rationale:      "A key of a basic type, such as a string, may collide with the keys of other packages, a key of an unexported type cannot.",
+		Category:       "bugs",
+		DefaultEnabled: true,
+		Examples: []lint.RuleExample{{
+			Bad: "ctx = context.WithValue(ctx, \"user\", u)",
+			Good: `type userKey struct{}
+
+ctx = context.WithValue(ctx, userKey{}, u)`,
+		}},
+	}
+}
+
 type lintContextKeyTypes struct {
 	file      *lint.File
 	fileAst   *ast.File
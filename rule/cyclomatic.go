@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"go/ast"
 	"go/token"
-	"sync"
 
 	"github.com/mgechev/revive/lint"
 )
@@ -13,32 +12,35 @@ import (
 
 // CyclomaticRule lints given else constructs.
 type CyclomaticRule struct {
+	configureOnce configureOnce
+
 	maxComplexity int
-	sync.Mutex
 }
 
 const defaultMaxCyclomaticComplexity = 10
 
-func (r *CyclomaticRule) configure(arguments lint.Arguments) {
-	r.Lock()
-	defer r.Unlock()
-	if r.maxComplexity == 0 {
-		if len(arguments) < 1 {
-			r.maxComplexity = defaultMaxCyclomaticComplexity
-			return
-		}
+// Configure validates the arguments of the rule and configures it.
+func (r *CyclomaticRule) Configure(arguments lint.Arguments) error {
+	return r.configureOnce.do(func() error { return r.configure(arguments) })
+}
 
-		complexity, ok := arguments[0].(int64) // Alt. non panicking version
-		if !ok {
-			panic(fmt.Sprintf("invalid argument for cyclomatic complexity; expected int but got %T", arguments[0]))
-		}
-		r.maxComplexity = int(complexity)
+func (r *CyclomaticRule) configure(arguments lint.Arguments) error {
+	if len(arguments) < 1 {
+		r.maxComplexity = defaultMaxCyclomaticComplexity
+		return nil
+	}
+
+	complexity, ok := arguments[0].(int64)
+	if !ok {
+		return fmt.Errorf("invalid argument for cyclomatic complexity; expected int but got %T", arguments[0])
 	}
+	r.maxComplexity = int(complexity)
+	return nil
 }
 
 // Apply applies the rule to given file.
 func (r *CyclomaticRule) Apply(file *lint.File, arguments lint.Arguments) []lint.Failure {
-	r.configure(arguments)
+	r.configureOnce.ensure(func() error { return r.configure(arguments) })
 
 	var failures []lint.Failure
 	fileAst := file.AST
@@ -61,6 +63,18 @@ func (*CyclomaticRule) Name() string {
 	return "cyclomatic"
 }
 
+// Metadata returns the description of the rule.
+func (*CyclomaticRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports functions whose cyclomatic complexity, the number of independent paths through their code, exceeds a maximum.",
+		Rationale:   "Complex functions are hard to test and to maintain, splitting them makes them easier to understand.",
+		Category:    "complexity",
+		Arguments: []lint.RuleArgument{
+			{Name: "max", Type: "integer", Description: "maximum cyclomatic complexity of a function, 10 by default"},
+		},
+	}
+}
+
 type lintCyclomatic struct {
 	file       *lint.File
 	complexity int
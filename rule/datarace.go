@@ -28,6 +28,31 @@ func (*DataRaceRule) Name() string {
 	return "datarace"
 }
 
+// Metadata returns the description of the rule.
+func (*DataRaceRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports goroutines capturing the named results of their enclosing function, or the range values of their loop.",
+		Rationale:   "The goroutine may access the variable while the function returns it, or while the loop updates it: a data race.",
+		Category:    "bugs",
+		Examples: []lint.RuleExample{{
+			Bad: `func fetch() (err error) {
+	go func() {
+		err = refresh()
+	}()
+	return nil
+}`,
+			Good: `func fetch() error {
+	go func() {
+		if err := refresh(); err != nil {
+			log.Print(err)
+		}
+	}()
+	return nil
+}`,
+		}},
+	}
+}
+
 type lintDataRaces struct {
 	onFailure func(failure lint.Failure)
 	go122for  bool
@@ -40,6 +40,27 @@ func (*DeepExitRule) Name() string {
 	return "deep-exit"
 }
 
+// Metadata returns the description of the rule.
+func (*DeepExitRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports calls to os.Exit and log.Fatal outside of the main and init functions, and of tests.",
+		Rationale:   "A function exiting the program cannot be reused: the caller cannot handle the failure, nor run its deferred calls.",
+		Category:    "bugs",
+		Examples: []lint.RuleExample{{
+			Bad: `func load(path string) []byte {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return data
+}`,
+			Good: `func load(path string) ([]byte, error) {
+	return os.ReadFile(path)
+}`,
+		}},
+	}
+}
+
 type lintDeepExit struct {
 	onFailure     func(lint.Failure)
 	exitFunctions map[string]map[string]bool
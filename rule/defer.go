@@ -3,28 +3,31 @@ package rule
 import (
 	"fmt"
 	"go/ast"
-	"sync"
 
 	"github.com/mgechev/revive/lint"
 )
 
 // DeferRule lints unused params in functions.
 type DeferRule struct {
+	configureOnce configureOnce
+
 	allow map[string]bool
-	sync.Mutex
 }
 
-func (r *DeferRule) configure(arguments lint.Arguments) {
-	r.Lock()
-	if r.allow == nil {
-		r.allow = r.allowFromArgs(arguments)
-	}
-	r.Unlock()
+// Configure validates the arguments of the rule and configures it.
+func (r *DeferRule) Configure(arguments lint.Arguments) error {
+	return r.configureOnce.do(func() error { return r.configure(arguments) })
+}
+
+func (r *DeferRule) configure(arguments lint.Arguments) error {
+	var err error
+	r.allow, err = r.allowFromArgs(arguments)
+	return err
 }
 
 // Apply applies the rule to given file.
 func (r *DeferRule) Apply(file *lint.File, arguments lint.Arguments) []lint.Failure {
-	r.configure(arguments)
+	r.configureOnce.ensure(func() error { return r.configure(arguments) })
 
 	var failures []lint.Failure
 	onFailure := func(failure lint.Failure) {
@@ -42,7 +45,33 @@ func (*DeferRule) Name() string {
 	return "defer"
 }
 
-func (*DeferRule) allowFromArgs(args lint.Arguments) map[string]bool {
+// Metadata returns the description of the rule.
+func (*DeferRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports common mistakes with defer: deferred call chains, defers in loops, deferred method calls, recover calls outside of deferred functions or deferred directly, and return values of deferred functions.",
+		Rationale:   "These defers do not behave as they seem to: e.g. the deferred calls of a loop only run when the function returns.",
+		Category:    "bugs",
+		Arguments: []lint.RuleArgument{
+			{Name: "checks", Type: "list of strings", Description: "the checks to enable among call-chain, loop, method-call, recover, immediate-recover and return, all of them by default"},
+		},
+		Examples: []lint.RuleExample{{
+			Bad: `for _, path := range paths {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+}`,
+			Good: `for _, path := range paths {
+	if err := process(path); err != nil {
+		return err
+	}
+}`,
+		}},
+	}
+}
+
+func (*DeferRule) allowFromArgs(args lint.Arguments) (map[string]bool, error) {
 	if len(args) < 1 {
 		allow := map[string]bool{
 			"loop":              true,
@@ -53,24 +82,24 @@ func (*DeferRule) allowFromArgs(args lint.Arguments) map[string]bool {
 			"immediate-recover": true,
 		}
 
-		return allow
+		return allow, nil
 	}
 
 	aa, ok := args[0].([]any)
 	if !ok {
-		panic(fmt.Sprintf("Invalid argument '%v' for 'defer' rule. Expecting []string, got %T", args[0], args[0]))
+		return nil, fmt.Errorf("invalid argument '%v' for 'defer' rule. Expecting []string, got %T", args[0], args[0])
 	}
 
 	allow := make(map[string]bool, len(aa))
 	for _, subcase := range aa {
 		sc, ok := subcase.(string)
 		if !ok {
-			panic(fmt.Sprintf("Invalid argument '%v' for 'defer' rule. Expecting string, got %T", subcase, subcase))
+			return nil, fmt.Errorf("invalid argument '%v' for 'defer' rule. Expecting string, got %T", subcase, subcase)
 		}
 		allow[sc] = true
 	}
 
-	return allow
+	return allow, nil
 }
 
 type lintDeferRule struct {
@@ -3,20 +3,20 @@ package rule
 import (
 	"fmt"
 	"go/ast"
-	"sync"
 
 	"github.com/mgechev/revive/lint"
 )
 
 // DotImportsRule lints given else constructs.
 type DotImportsRule struct {
-	sync.Mutex
+	configureOnce configureOnce
+
 	allowedPackages allowPackages
 }
 
 // Apply applies the rule to given file.
 func (r *DotImportsRule) Apply(file *lint.File, arguments lint.Arguments) []lint.Failure {
-	r.configure(arguments)
+	r.configureOnce.ensure(func() error { return r.configure(arguments) })
 
 	var failures []lint.Failure
 
@@ -40,37 +40,57 @@ func (*DotImportsRule) Name() string {
 	return "dot-imports"
 }
 
-func (r *DotImportsRule) configure(arguments lint.Arguments) {
-	r.Lock()
-	defer r.Unlock()
+// Metadata returns the description of the rule.
+func (*DotImportsRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description:    "Reports dot imports.",
+		Rationale:      "A dot import makes it unclear whether a name belongs to the current package or to the imported one.",
+		Category:       "imports",
+		DefaultEnabled: true,
+		Arguments: []lint.RuleArgument{
+			{Name: "allowedPackages", Type: "list of strings", Description: "import paths of the packages allowed to be dot imported, given as [{ allowedPackages = [...] }]"},
+		},
+		Examples: []lint.RuleExample{{
+			Bad: `import . "math"
+
+var root = Sqrt(2)`,
+			Good: `import "math"
 
-	if r.allowedPackages != nil {
-		return
+var root = math.Sqrt(2)`,
+		}},
 	}
+}
 
+// Configure validates the arguments of the rule and configures it.
+func (r *DotImportsRule) Configure(arguments lint.Arguments) error {
+	return r.configureOnce.do(func() error { return r.configure(arguments) })
+}
+
+func (r *DotImportsRule) configure(arguments lint.Arguments) error {
 	r.allowedPackages = make(allowPackages)
 	if len(arguments) == 0 {
-		return
+		return nil
 	}
 
 	args, ok := arguments[0].(map[string]any)
 	if !ok {
-		panic(fmt.Sprintf("Invalid argument to the dot-imports rule. Expecting a k,v map, got %T", arguments[0]))
+		return fmt.Errorf("invalid argument to the dot-imports rule. Expecting a k,v map, got %T", arguments[0])
 	}
 
 	if allowedPkgArg, ok := args["allowedPackages"]; ok {
-		if pkgs, ok := allowedPkgArg.([]any); ok {
-			for _, p := range pkgs {
-				if pkg, ok := p.(string); ok {
-					r.allowedPackages.add(pkg)
-				} else {
-					panic(fmt.Sprintf("Invalid argument to the dot-imports rule, string expected. Got '%v' (%T)", p, p))
-				}
+		pkgs, ok := allowedPkgArg.([]any)
+		if !ok {
+			return fmt.Errorf("invalid argument to the dot-imports rule, []string expected. Got '%v' (%T)", allowedPkgArg, allowedPkgArg)
+		}
+		for _, p := range pkgs {
+			pkg, ok := p.(string)
+			if !ok {
+				return fmt.Errorf("invalid argument to the dot-imports rule, string expected. Got '%v' (%T)", p, p)
 			}
-		} else {
-			panic(fmt.Sprintf("Invalid argument to the dot-imports rule, []string expected. Got '%v' (%T)", allowedPkgArg, allowedPkgArg))
+			r.allowedPackages.add(pkg)
 		}
 	}
+	return nil
 }
 
 type lintImports struct {
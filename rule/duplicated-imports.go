@@ -37,3 +37,21 @@ func (*DuplicatedImportsRule) Apply(file *lint.File, _ lint.Arguments) []lint.Fa
 func (*DuplicatedImportsRule) Name() string {
 	return "duplicated-imports"
 }
+
+// Metadata returns the description of the rule.
+func (*DuplicatedImportsRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports packages imported more than once.",
+		Rationale:   "A duplicated import is useless, the package is available under a single name.",
+		Category:    "imports",
+		Examples: []lint.RuleExample{{
+			Bad: `import (
+	"strings"
+	str "strings"
+)`,
+			Good: `import (
+	"strings"
+)`,
+		}},
+	}
+}
@@ -13,7 +13,7 @@ type EarlyReturnRule struct{}
 
 // Apply applies the rule to given file.
 func (e *EarlyReturnRule) Apply(file *lint.File, args lint.Arguments) []lint.Failure {
-	return ifelse.Apply(e, file.AST, ifelse.TargetIf, args)
+	return ifelse.Apply(e, file, ifelse.TargetIf, args)
 }
 
 // Name returns the rule name.
@@ -21,6 +21,29 @@ func (*EarlyReturnRule) Name() string {
 	return "early-return"
 }
 
+// Metadata returns the description of the rule.
+func (*EarlyReturnRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports if-else statements whose else block ends with a return, whose condition can be inverted to return early.",
+		Rationale:   "Returning early keeps the normal flow of the function at the minimal indentation.",
+		Category:    "style",
+		Arguments: []lint.RuleArgument{
+			{Name: "flags", Type: "list of strings", Description: "preserveScope does not suggest refactorings that would increase the scope of variables"},
+		},
+		Examples: []lint.RuleExample{{
+			Bad: `if ok {
+	process()
+} else {
+	return errNotFound
+}`,
+			Good: `if !ok {
+	return errNotFound
+}
+process()`,
+		}},
+	}
+}
+
 // CheckIfElse evaluates the rule against an ifelse.Chain.
 func (*EarlyReturnRule) CheckIfElse(chain ifelse.Chain, args ifelse.Args) (failMsg string) {
 	if !chain.Else.Deviates() {
@@ -27,6 +27,23 @@ func (*EmptyBlockRule) Name() string {
 	return "empty-block"
 }
 
+// Metadata returns the description of the rule.
+func (*EmptyBlockRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description:    "Reports empty blocks.",
+		Rationale:      "An empty block does nothing: it is useless, or the sign of unfinished code.",
+		Category:       "logic",
+		DefaultEnabled: true,
+		Examples: []lint.RuleExample{{
+			Bad: `if err != nil {
+}`,
+			Good: `if err != nil {
+	return err
+}`,
+		}},
+	}
+}
+
 type lintEmptyBlock struct {
 	ignore    map[*ast.BlockStmt]bool
 	onFailure func(lint.Failure)
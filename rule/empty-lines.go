@@ -28,6 +28,24 @@ func (*EmptyLinesRule) Name() string {
 	return "empty-lines"
 }
 
+// Metadata returns the description of the rule.
+func (*EmptyLinesRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports empty lines at the start or at the end of blocks.",
+		Rationale:   "The empty lines add nothing to the block, gofmt does not remove them.",
+		Category:    "style",
+		Examples: []lint.RuleExample{{
+			Bad: `func f() {
+
+	g()
+}`,
+			Good: `func f() {
+	g()
+}`,
+		}},
+	}
+}
+
 type lintEmptyLines struct {
 	file      *lint.File
 	cmap      map[int]struct{}
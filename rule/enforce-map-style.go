@@ -3,7 +3,6 @@ package rule
 import (
 	"fmt"
 	"go/ast"
-	"sync"
 
 	"github.com/mgechev/revive/lint"
 )
@@ -39,41 +38,38 @@ func mapStyleFromString(s string) (enforceMapStyleType, error) {
 
 // EnforceMapStyleRule implements a rule to enforce `make(map[type]type)` over `map[type]type{}`.
 type EnforceMapStyleRule struct {
-	configured      bool
+	configureOnce configureOnce
+
 	enforceMapStyle enforceMapStyleType
-	sync.Mutex
 }
 
-func (r *EnforceMapStyleRule) configure(arguments lint.Arguments) {
-	r.Lock()
-	defer r.Unlock()
-
-	if r.configured {
-		return
-	}
-	r.configured = true
+// Configure validates the arguments of the rule and configures it.
+func (r *EnforceMapStyleRule) Configure(arguments lint.Arguments) error {
+	return r.configureOnce.do(func() error { return r.configure(arguments) })
+}
 
+func (r *EnforceMapStyleRule) configure(arguments lint.Arguments) error {
 	if len(arguments) < 1 {
 		r.enforceMapStyle = enforceMapStyleTypeAny
-		return
+		return nil
 	}
 
 	enforceMapStyle, ok := arguments[0].(string)
 	if !ok {
-		panic(fmt.Sprintf("Invalid argument '%v' for 'enforce-map-style' rule. Expecting string, got %T", arguments[0], arguments[0]))
+		return fmt.Errorf("invalid argument '%v' for 'enforce-map-style' rule. Expecting string, got %T", arguments[0], arguments[0])
 	}
 
 	var err error
 	r.enforceMapStyle, err = mapStyleFromString(enforceMapStyle)
-
 	if err != nil {
-		panic(fmt.Sprintf("Invalid argument to the enforce-map-style rule: %v", err))
+		return fmt.Errorf("invalid argument to the enforce-map-style rule: %w", err)
 	}
+	return nil
 }
 
 // Apply applies the rule to given file.
 func (r *EnforceMapStyleRule) Apply(file *lint.File, arguments lint.Arguments) []lint.Failure {
-	r.configure(arguments)
+	r.configureOnce.ensure(func() error { return r.configure(arguments) })
 
 	if r.enforceMapStyle == enforceMapStyleTypeAny {
 		// this linter is not configured
@@ -145,6 +141,22 @@ func (*EnforceMapStyleRule) Name() string {
 	return "enforce-map-style"
 }
 
+// Metadata returns the description of the rule.
+func (*EnforceMapStyleRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports empty maps not initialized in the configured style, with make or with a literal.",
+		Rationale:   "A single style of initialization makes the code consistent.",
+		Category:    "style",
+		Arguments: []lint.RuleArgument{
+			{Name: "style", Type: "string", Description: "any (default), make for make(map[K]V) or literal for map[K]V{}"},
+		},
+		Examples: []lint.RuleExample{{
+			Bad:  `counts := map[string]int{}`,
+			Good: `counts := make(map[string]int)`,
+		}},
+	}
+}
+
 func (r *EnforceMapStyleRule) isMapType(v ast.Expr) bool {
 	switch t := v.(type) {
 	case *ast.MapType:
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"go/ast"
 	"go/types"
-	"sync"
 
 	"github.com/mgechev/revive/lint"
 )
@@ -17,17 +16,17 @@ const (
 	enforceRepeatedArgTypeStyleTypeFull  enforceRepeatedArgTypeStyleType = "full"
 )
 
-func repeatedArgTypeStyleFromString(s string) enforceRepeatedArgTypeStyleType {
+func repeatedArgTypeStyleFromString(s string) (enforceRepeatedArgTypeStyleType, error) {
 	switch s {
 	case string(enforceRepeatedArgTypeStyleTypeAny), "":
-		return enforceRepeatedArgTypeStyleTypeAny
+		return enforceRepeatedArgTypeStyleTypeAny, nil
 	case string(enforceRepeatedArgTypeStyleTypeShort):
-		return enforceRepeatedArgTypeStyleTypeShort
+		return enforceRepeatedArgTypeStyleTypeShort, nil
 	case string(enforceRepeatedArgTypeStyleTypeFull):
-		return enforceRepeatedArgTypeStyleTypeFull
+		return enforceRepeatedArgTypeStyleTypeFull, nil
 	default:
-		err := fmt.Errorf(
-			"invalid repeated arg type style: %s (expecting one of %v)",
+		return enforceRepeatedArgTypeStyleTypeAny, fmt.Errorf(
+			"invalid argument to the enforce-repeated-arg-type-style rule: invalid repeated arg type style: %s (expecting one of %v)",
 			s,
 			[]enforceRepeatedArgTypeStyleType{
 				enforceRepeatedArgTypeStyleTypeAny,
@@ -35,67 +34,75 @@ func repeatedArgTypeStyleFromString(s string) enforceRepeatedArgTypeStyleType {
 				enforceRepeatedArgTypeStyleTypeFull,
 			},
 		)
-
-		panic(fmt.Sprintf("Invalid argument to the enforce-repeated-arg-type-style rule: %v", err))
 	}
 }
 
 // EnforceRepeatedArgTypeStyleRule implements a rule to enforce repeated argument type style.
 type EnforceRepeatedArgTypeStyleRule struct {
-	configured      bool
+	configureOnce configureOnce
+
 	funcArgStyle    enforceRepeatedArgTypeStyleType
 	funcRetValStyle enforceRepeatedArgTypeStyleType
-
-	sync.Mutex
 }
 
-func (r *EnforceRepeatedArgTypeStyleRule) configure(arguments lint.Arguments) {
-	r.Lock()
-	defer r.Unlock()
-
-	if r.configured {
-		return
-	}
-	r.configured = true
+// Configure validates the arguments of the rule and configures it.
+func (r *EnforceRepeatedArgTypeStyleRule) Configure(arguments lint.Arguments) error {
+	return r.configureOnce.do(func() error { return r.configure(arguments) })
+}
 
+func (r *EnforceRepeatedArgTypeStyleRule) configure(arguments lint.Arguments) error {
 	r.funcArgStyle = enforceRepeatedArgTypeStyleTypeAny
 	r.funcRetValStyle = enforceRepeatedArgTypeStyleTypeAny
 
 	if len(arguments) == 0 {
-		return
+		return nil
 	}
 
+	var err error
 	switch funcArgStyle := arguments[0].(type) {
 	case string:
-		r.funcArgStyle = repeatedArgTypeStyleFromString(funcArgStyle)
-		r.funcRetValStyle = repeatedArgTypeStyleFromString(funcArgStyle)
+		r.funcArgStyle, err = repeatedArgTypeStyleFromString(funcArgStyle)
+		if err != nil {
+			return err
+		}
+		r.funcRetValStyle, err = repeatedArgTypeStyleFromString(funcArgStyle)
+		if err != nil {
+			return err
+		}
 	case map[string]any: // expecting map[string]string
 		for k, v := range funcArgStyle {
 			switch k {
 			case "funcArgStyle":
 				val, ok := v.(string)
 				if !ok {
-					panic(fmt.Sprintf("Invalid map value type for 'enforce-repeated-arg-type-style' rule. Expecting string, got %T", v))
+					return fmt.Errorf("invalid map value type for 'enforce-repeated-arg-type-style' rule. Expecting string, got %T", v)
+				}
+				r.funcArgStyle, err = repeatedArgTypeStyleFromString(val)
+				if err != nil {
+					return err
 				}
-				r.funcArgStyle = repeatedArgTypeStyleFromString(val)
 			case "funcRetValStyle":
 				val, ok := v.(string)
 				if !ok {
-					panic(fmt.Sprintf("Invalid map value '%v' for 'enforce-repeated-arg-type-style' rule. Expecting string, got %T", v, v))
+					return fmt.Errorf("invalid map value '%v' for 'enforce-repeated-arg-type-style' rule. Expecting string, got %T", v, v)
+				}
+				r.funcRetValStyle, err = repeatedArgTypeStyleFromString(val)
+				if err != nil {
+					return err
 				}
-				r.funcRetValStyle = repeatedArgTypeStyleFromString(val)
 			default:
-				panic(fmt.Sprintf("Invalid map key for 'enforce-repeated-arg-type-style' rule. Expecting 'funcArgStyle' or 'funcRetValStyle', got %v", k))
+				return fmt.Errorf("invalid map key for 'enforce-repeated-arg-type-style' rule. Expecting 'funcArgStyle' or 'funcRetValStyle', got %v", k)
 			}
 		}
 	default:
-		panic(fmt.Sprintf("Invalid argument '%v' for 'import-alias-naming' rule. Expecting string or map[string]string, got %T", arguments[0], arguments[0]))
+		return fmt.Errorf("invalid argument '%v' for 'enforce-repeated-arg-type-style' rule. Expecting string or map[string]string, got %T", arguments[0], arguments[0])
 	}
+	return nil
 }
 
 // Apply applies the rule to a given file.
 func (r *EnforceRepeatedArgTypeStyleRule) Apply(file *lint.File, arguments lint.Arguments) []lint.Failure {
-	r.configure(arguments)
+	r.configureOnce.ensure(func() error { return r.configure(arguments) })
 
 	if r.funcArgStyle == enforceRepeatedArgTypeStyleTypeAny && r.funcRetValStyle == enforceRepeatedArgTypeStyleTypeAny {
 		// This linter is not configured, return no failures.
@@ -189,3 +196,19 @@ func (r *EnforceRepeatedArgTypeStyleRule) Apply(file *lint.File, arguments lint.
 func (*EnforceRepeatedArgTypeStyleRule) Name() string {
 	return "enforce-repeated-arg-type-style"
 }
+
+// Metadata returns the description of the rule.
+func (*EnforceRepeatedArgTypeStyleRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports parameters and results of the same type not declared in the configured style, with the type repeated or not.",
+		Rationale:   "A single style of declaration makes the signatures consistent.",
+		Category:    "style",
+		Arguments: []lint.RuleArgument{
+			{Name: "style", Type: "string or table", Description: "any (default), short or full for both the parameters and the results, or a table with funcArgStyle and funcRetValStyle keys"},
+		},
+		Examples: []lint.RuleExample{{
+			Bad:  `func add(a int, b int) int`,
+			Good: `func add(a, b int) int`,
+		}},
+	}
+}
@@ -3,7 +3,6 @@ package rule
 import (
 	"fmt"
 	"go/ast"
-	"sync"
 
 	"github.com/mgechev/revive/lint"
 )
@@ -43,41 +42,38 @@ func sliceStyleFromString(s string) (enforceSliceStyleType, error) {
 
 // EnforceSliceStyleRule implements a rule to enforce `make([]type)` over `[]type{}`.
 type EnforceSliceStyleRule struct {
-	configured        bool
+	configureOnce configureOnce
+
 	enforceSliceStyle enforceSliceStyleType
-	sync.Mutex
 }
 
-func (r *EnforceSliceStyleRule) configure(arguments lint.Arguments) {
-	r.Lock()
-	defer r.Unlock()
-
-	if r.configured {
-		return
-	}
-	r.configured = true
+// Configure validates the arguments of the rule and configures it.
+func (r *EnforceSliceStyleRule) Configure(arguments lint.Arguments) error {
+	return r.configureOnce.do(func() error { return r.configure(arguments) })
+}
 
+func (r *EnforceSliceStyleRule) configure(arguments lint.Arguments) error {
 	if len(arguments) < 1 {
 		r.enforceSliceStyle = enforceSliceStyleTypeAny
-		return
+		return nil
 	}
 
 	enforceSliceStyle, ok := arguments[0].(string)
 	if !ok {
-		panic(fmt.Sprintf("Invalid argument '%v' for 'enforce-slice-style' rule. Expecting string, got %T", arguments[0], arguments[0]))
+		return fmt.Errorf("invalid argument '%v' for 'enforce-slice-style' rule. Expecting string, got %T", arguments[0], arguments[0])
 	}
 
 	var err error
 	r.enforceSliceStyle, err = sliceStyleFromString(enforceSliceStyle)
-
 	if err != nil {
-		panic(fmt.Sprintf("Invalid argument to the enforce-slice-style rule: %v", err))
+		return fmt.Errorf("invalid argument to the enforce-slice-style rule: %w", err)
 	}
+	return nil
 }
 
 // Apply applies the rule to given file.
 func (r *EnforceSliceStyleRule) Apply(file *lint.File, arguments lint.Arguments) []lint.Failure {
-	r.configure(arguments)
+	r.configureOnce.ensure(func() error { return r.configure(arguments) })
 
 	if r.enforceSliceStyle == enforceSliceStyleTypeAny {
 		// this linter is not configured
@@ -190,6 +186,22 @@ func (*EnforceSliceStyleRule) Name() string {
 	return "enforce-slice-style"
 }
 
+// Metadata returns the description of the rule.
+func (*EnforceSliceStyleRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports empty slices not initialized in the configured style, with make, with a literal or as nil.",
+		Rationale:   "A single style of initialization makes the code consistent.",
+		Category:    "style",
+		Arguments: []lint.RuleArgument{
+			{Name: "style", Type: "string", Description: "any (default), make for make([]T, 0), literal for []T{} or nil for var s []T"},
+		},
+		Examples: []lint.RuleExample{{
+			Bad:  `names := []string{}`,
+			Good: `names := make([]string, 0)`,
+		}},
+	}
+}
+
 func (r *EnforceSliceStyleRule) isSliceType(v ast.Expr) bool {
 	switch t := v.(type) {
 	case *ast.ArrayType:
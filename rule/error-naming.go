@@ -35,6 +35,20 @@ func (*ErrorNamingRule) Name() string {
 	return "error-naming"
 }
 
+// Metadata returns the description of the rule.
+func (*ErrorNamingRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description:    "Reports error variables whose name is not prefixed by err or Err.",
+		Rationale:      "The prefix makes the errors of a package recognizable, e.g. when comparing errors with errors.Is.",
+		Category:       "naming",
+		DefaultEnabled: true,
+		Examples: []lint.RuleExample{{
+			Bad:  "var NotFound = errors.New(\"not found\")",
+			Good: "var ErrNotFound = errors.New(\"not found\")",
+		}},
+	}
+}
+
 type lintErrors struct {
 	file      *lint.File
 	fileAst   *ast.File
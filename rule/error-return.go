@@ -32,6 +32,20 @@ func (*ErrorReturnRule) Name() string {
 	return "error-return"
 }
 
+// Metadata returns the description of the rule.
+func (*ErrorReturnRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description:    "Reports functions returning an error that is not their last result.",
+		Rationale:      "By convention, the error is the last result of a function.",
+		Category:       "errors",
+		DefaultEnabled: true,
+		Examples: []lint.RuleExample{{
+			Bad:  "func Load(path string) (error, *Config)",
+			Good: "func Load(path string) (*Config, error)",
+		}},
+	}
+}
+
 type lintErrorReturn struct {
 	file      *lint.File
 	fileAst   *ast.File
@@ -1,11 +1,11 @@
 package rule
 
 import (
+	"errors"
 	"go/ast"
 	"go/token"
 	"strconv"
 	"strings"
-	"sync"
 	"unicode"
 	"unicode/utf8"
 
@@ -14,18 +14,17 @@ import (
 
 // ErrorStringsRule lints given else constructs.
 type ErrorStringsRule struct {
+	configureOnce configureOnce
+
 	errorFunctions map[string]map[string]struct{}
-	sync.Mutex
 }
 
-func (r *ErrorStringsRule) configure(arguments lint.Arguments) {
-	r.Lock()
-	defer r.Unlock()
-
-	if r.errorFunctions != nil {
-		return
-	}
+// Configure validates the arguments of the rule and configures it.
+func (r *ErrorStringsRule) Configure(arguments lint.Arguments) error {
+	return r.configureOnce.do(func() error { return r.configure(arguments) })
+}
 
+func (r *ErrorStringsRule) configure(arguments lint.Arguments) error {
 	r.errorFunctions = map[string]map[string]struct{}{
 		"fmt": {
 			"Errorf": {},
@@ -52,15 +51,16 @@ func (r *ErrorStringsRule) configure(arguments lint.Arguments) {
 		}
 	}
 	if len(invalidCustomFunctions) != 0 {
-		panic("found invalid custom function: " + strings.Join(invalidCustomFunctions, ","))
+		return errors.New("found invalid custom function: " + strings.Join(invalidCustomFunctions, ","))
 	}
+	return nil
 }
 
 // Apply applies the rule to given file.
 func (r *ErrorStringsRule) Apply(file *lint.File, arguments lint.Arguments) []lint.Failure {
-	var failures []lint.Failure
+	r.configureOnce.ensure(func() error { return r.configure(arguments) })
 
-	r.configure(arguments)
+	var failures []lint.Failure
 
 	fileAst := file.AST
 	walker := lintErrorStrings{
@@ -82,6 +82,23 @@ func (*ErrorStringsRule) Name() string {
 	return "error-strings"
 }
 
+// Metadata returns the description of the rule.
+func (*ErrorStringsRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description:    "Reports error messages that are capitalized or end with punctuation or a newline.",
+		Rationale:      "Error messages are usually wrapped in other messages, e.g. \"reading config: file not found\", where capitals and punctuation are out of place.",
+		Category:       "errors",
+		DefaultEnabled: true,
+		Arguments: []lint.RuleArgument{
+			{Name: "functions", Type: "list of strings", Description: "additional functions creating errors from their first argument, e.g. \"xerrors.New\""},
+		},
+		Examples: []lint.RuleExample{{
+			Bad:  "return errors.New(\"File not found.\")",
+			Good: "return errors.New(\"file not found\")",
+		}},
+	}
+}
+
 type lintErrorStrings struct {
 	file           *lint.File
 	fileAst        *ast.File
@@ -17,7 +17,7 @@ func (*ErrorfRule) Apply(file *lint.File, _ lint.Arguments) []lint.Failure {
 	var failures []lint.Failure
 
 	fileAst := file.AST
-	walker := lintErrorf{
+	walker := &lintErrorf{
 		file:    file,
 		fileAst: fileAst,
 		onFailure: func(failure lint.Failure) {
@@ -28,6 +28,13 @@ func (*ErrorfRule) Apply(file *lint.File, _ lint.Arguments) []lint.Failure {
 	file.Pkg.TypeCheck()
 	ast.Walk(walker, fileAst)
 
+	if walker.errorsUses == len(walker.errorsNewFailures) {
+		// fixing all errors.New calls would leave the "errors" import unused
+		for _, idx := range walker.errorsNewFailures {
+			failures[idx].Edits = nil
+		}
+	}
+
 	return failures
 }
 
@@ -36,13 +43,36 @@ func (*ErrorfRule) Name() string {
 	return "errorf"
 }
 
+// Metadata returns the description of the rule.
+func (*ErrorfRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description:    "Reports errors.New(fmt.Sprintf(...)) calls, that can be replaced by fmt.Errorf(...).",
+		Rationale:      "fmt.Errorf formats the message and creates the error in one call.",
+		Category:       "errors",
+		DefaultEnabled: true,
+		Examples: []lint.RuleExample{{
+			Bad:  "err := errors.New(fmt.Sprintf(\"invalid id %d\", id))",
+			Good: "err := fmt.Errorf(\"invalid id %d\", id)",
+		}},
+	}
+}
+
 type lintErrorf struct {
 	file      *lint.File
 	fileAst   *ast.File
 	onFailure func(lint.Failure)
+	// errorsUses is the number of references to the errors package
+	errorsUses int
+	// errorsNewFailures holds the indexes of failures on errors.New calls
+	errorsNewFailures []int
+	failuresCount     int
 }
 
-func (w lintErrorf) Visit(n ast.Node) ast.Visitor {
+func (w *lintErrorf) Visit(n ast.Node) ast.Visitor {
+	if se, ok := n.(*ast.SelectorExpr); ok && isIdent(se.X, "errors") {
+		w.errorsUses++
+	}
+
 	ce, ok := n.(*ast.CallExpr)
 	if !ok || len(ce.Args) != 1 {
 		return w
@@ -58,6 +88,7 @@ func (w lintErrorf) Visit(n ast.Node) ast.Visitor {
 	if !isErrorsNew && !isTestingError {
 		return w
 	}
+	call := ce
 	arg := ce.Args[0]
 	ce, ok = arg.(*ast.CallExpr)
 	if !ok || !isPkgDot(ce.Fun, "fmt", "Sprintf") {
@@ -73,6 +104,10 @@ func (w lintErrorf) Visit(n ast.Node) ast.Visitor {
 		Node:       n,
 		Confidence: 1,
 		Failure:    fmt.Sprintf("should replace %s(fmt.Sprintf(...)) with %s.Errorf(...)", w.file.Render(se), errorfPrefix),
+		Edits: []lint.Edit{
+			lint.ToEdit(call.Pos(), ce.Lparen+1, errorfPrefix+".Errorf(", w.file),
+			lint.ToEdit(ce.Rparen, call.End(), ")", w.file),
+		},
 	}
 
 	m := srcLineWithMatch(w.file, ce, `^(.*)`+w.file.Render(se)+`\(fmt\.Sprintf\((.*)\)\)(.*)$`)
@@ -80,6 +115,10 @@ func (w lintErrorf) Visit(n ast.Node) ast.Visitor {
 		failure.ReplacementLine = m[1] + errorfPrefix + ".Errorf(" + m[2] + ")" + m[3]
 	}
 
+	if isErrorsNew {
+		w.errorsNewFailures = append(w.errorsNewFailures, w.failuresCount)
+	}
+	w.failuresCount++
 	w.onFailure(failure)
 
 	return w
@@ -5,7 +5,6 @@ import (
 	"go/ast"
 	"go/token"
 	"strings"
-	"sync"
 	"unicode"
 	"unicode/utf8"
 
@@ -15,44 +14,48 @@ import (
 
 // ExportedRule lints given else constructs.
 type ExportedRule struct {
-	configured             bool
+	configureOnce configureOnce
+
 	checkPrivateReceivers  bool
 	disableStutteringCheck bool
 	checkPublicInterface   bool
 	stuttersMsg            string
-	sync.Mutex
 }
 
-func (r *ExportedRule) configure(arguments lint.Arguments) {
-	r.Lock()
-	defer r.Unlock()
-	if !r.configured {
-		r.stuttersMsg = "stutters"
-		for _, flag := range arguments {
-			flagStr, ok := flag.(string)
-			if !ok {
-				panic(fmt.Sprintf("Invalid argument for the %s rule: expecting a string, got %T", r.Name(), flag))
-			}
-			switch flagStr {
-			case "checkPrivateReceivers":
-				r.checkPrivateReceivers = true
-			case "disableStutteringCheck":
-				r.disableStutteringCheck = true
-			case "sayRepetitiveInsteadOfStutters":
-				r.stuttersMsg = "is repetitive"
-			case "checkPublicInterface":
-				r.checkPublicInterface = true
-			default:
-				panic(fmt.Sprintf("Unknown configuration flag %s for %s rule", flagStr, r.Name()))
-			}
+// Configure validates the arguments of the rule and configures it.
+func (r *ExportedRule) Configure(arguments lint.Arguments) error {
+	return r.configureOnce.do(func() error { return r.configure(arguments) })
+}
+
+func (r *ExportedRule) configure(arguments lint.Arguments) error {
+	r.checkPrivateReceivers = false
+	r.disableStutteringCheck = false
+	r.checkPublicInterface = false
+	r.stuttersMsg = "stutters"
+	for _, flag := range arguments {
+		flagStr, ok := flag.(string)
+		if !ok {
+			return fmt.Errorf("invalid argument for the %s rule: expecting a string, got %T", r.Name(), flag)
+		}
+		switch flagStr {
+		case "checkPrivateReceivers":
+			r.checkPrivateReceivers = true
+		case "disableStutteringCheck":
+			r.disableStutteringCheck = true
+		case "sayRepetitiveInsteadOfStutters":
+			r.stuttersMsg = "is repetitive"
+		case "checkPublicInterface":
+			r.checkPublicInterface = true
+		default:
+			return fmt.Errorf("unknown configuration flag %s for %s rule", flagStr, r.Name())
 		}
-		r.configured = true
 	}
+	return nil
 }
 
 // Apply applies the rule to given file.
-func (r *ExportedRule) Apply(file *lint.File, args lint.Arguments) []lint.Failure {
-	r.configure(args)
+func (r *ExportedRule) Apply(file *lint.File, arguments lint.Arguments) []lint.Failure {
+	r.configureOnce.ensure(func() error { return r.configure(arguments) })
 
 	var failures []lint.Failure
 	if file.IsTest() {
@@ -84,6 +87,24 @@ func (*ExportedRule) Name() string {
 	return "exported"
 }
 
+// Metadata returns the description of the rule.
+func (*ExportedRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description:    "Reports exported declarations without a doc comment, or with one not starting with their name, and names stuttering with the package name.",
+		Rationale:      "Exported declarations are the API of the package: their doc comments are its documentation.",
+		Category:       "comments",
+		DefaultEnabled: true,
+		Arguments: []lint.RuleArgument{
+			{Name: "flags", Type: "list of strings", Description: "checkPrivateReceivers checks the exported methods of unexported types, disableStutteringCheck disables the check of the names stuttering with the package name, sayRepetitiveInsteadOfStutters says repetitive instead of stutters in the messages, checkPublicInterface checks the methods of exported interfaces"},
+		},
+		Examples: []lint.RuleExample{{
+			Bad: "func Parse(s string) (int, error)",
+			Good: `// Parse returns the integer represented by s.
+func Parse(s string) (int, error)`,
+		}},
+	}
+}
+
 type lintExported struct {
 	file                   *lint.File
 	fileAst                *ast.File
@@ -3,15 +3,16 @@ package rule
 import (
 	"fmt"
 	"regexp"
-	"sync"
 
 	"github.com/mgechev/revive/lint"
 )
 
 // FileHeaderRule lints given else constructs.
 type FileHeaderRule struct {
+	configureOnce configureOnce
+
 	header string
-	sync.Mutex
+	regexp *regexp.Regexp
 }
 
 var (
@@ -19,25 +20,35 @@ var (
 	singleRegexp = regexp.MustCompile("^//")
 )
 
-func (r *FileHeaderRule) configure(arguments lint.Arguments) {
-	r.Lock()
-	defer r.Unlock()
-	if r.header == "" {
-		if len(arguments) < 1 {
-			return
-		}
+// Configure validates the arguments of the rule and configures it.
+func (r *FileHeaderRule) Configure(arguments lint.Arguments) error {
+	return r.configureOnce.do(func() error { return r.configure(arguments) })
+}
 
-		var ok bool
-		r.header, ok = arguments[0].(string)
-		if !ok {
-			panic(fmt.Sprintf("invalid argument for \"file-header\" rule: argument should be a string, got %T", arguments[0]))
-		}
+func (r *FileHeaderRule) configure(arguments lint.Arguments) error {
+	r.header = ""
+	r.regexp = nil
+	if len(arguments) < 1 {
+		return nil
+	}
+
+	var ok bool
+	r.header, ok = arguments[0].(string)
+	if !ok {
+		return fmt.Errorf("invalid argument for \"file-header\" rule: argument should be a string, got %T", arguments[0])
 	}
+
+	var err error
+	r.regexp, err = regexp.Compile(r.header)
+	if err != nil {
+		return fmt.Errorf("invalid argument for \"file-header\" rule: %w", err)
+	}
+	return nil
 }
 
 // Apply applies the rule to given file.
 func (r *FileHeaderRule) Apply(file *lint.File, arguments lint.Arguments) []lint.Failure {
-	r.configure(arguments)
+	r.configureOnce.ensure(func() error { return r.configure(arguments) })
 
 	if r.header == "" {
 		return nil
@@ -70,12 +81,7 @@ func (r *FileHeaderRule) Apply(file *lint.File, arguments lint.Arguments) []lint
 		comment += text
 	}
 
-	regex, err := regexp.Compile(r.header)
-	if err != nil {
-		panic(err.Error())
-	}
-
-	if !regex.MatchString(comment) {
+	if !r.regexp.MatchString(comment) {
 		return failure
 	}
 	return nil
@@ -85,3 +91,15 @@ func (r *FileHeaderRule) Apply(file *lint.File, arguments lint.Arguments) []lint
 func (*FileHeaderRule) Name() string {
 	return "file-header"
 }
+
+// Metadata returns the description of the rule.
+func (*FileHeaderRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports files whose first comment does not match the configured header.",
+		Rationale:   "A common header, such as a license notice, must appear in every file of some projects.",
+		Category:    "comments",
+		Arguments: []lint.RuleArgument{
+			{Name: "header", Type: "string", Description: "regular expression the header must match"},
+		},
+	}
+}
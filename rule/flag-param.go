@@ -28,6 +28,23 @@ func (*FlagParamRule) Name() string {
 	return "flag-parameter"
 }
 
+// Metadata returns the description of the rule.
+func (*FlagParamRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports boolean parameters used to control the flow of a function.",
+		Rationale:   "A flag parameter couples the function to its callers, two functions are usually clearer.",
+		Category:    "style",
+		Examples: []lint.RuleExample{{
+			Bad: `func render(w io.Writer, compact bool) {
+	if compact {
+		renderCompact(w)
+	}
+}`,
+			Good: `func renderCompact(w io.Writer)`,
+		}},
+	}
+}
+
 type lintFlagParamRule struct {
 	onFailure func(lint.Failure)
 }
@@ -4,33 +4,36 @@ import (
 	"fmt"
 	"go/ast"
 	"reflect"
-	"sync"
 
 	"github.com/mgechev/revive/lint"
 )
 
 // FunctionLength lint.
 type FunctionLength struct {
-	maxStmt    int
-	maxLines   int
-	configured bool
-	sync.Mutex
+	configureOnce configureOnce
+
+	maxStmt  int
+	maxLines int
+}
+
+// Configure validates the arguments of the rule and configures it.
+func (r *FunctionLength) Configure(arguments lint.Arguments) error {
+	return r.configureOnce.do(func() error { return r.configure(arguments) })
 }
 
-func (r *FunctionLength) configure(arguments lint.Arguments) {
-	r.Lock()
-	defer r.Unlock()
-	if !r.configured {
-		maxStmt, maxLines := r.parseArguments(arguments)
-		r.maxStmt = int(maxStmt)
-		r.maxLines = int(maxLines)
-		r.configured = true
+func (r *FunctionLength) configure(arguments lint.Arguments) error {
+	maxStmt, maxLines, err := r.parseArguments(arguments)
+	if err != nil {
+		return err
 	}
+	r.maxStmt = int(maxStmt)
+	r.maxLines = int(maxLines)
+	return nil
 }
 
 // Apply applies the rule to given file.
 func (r *FunctionLength) Apply(file *lint.File, arguments lint.Arguments) []lint.Failure {
-	r.configure(arguments)
+	r.configureOnce.ensure(func() error { return r.configure(arguments) })
 
 	var failures []lint.Failure
 
@@ -53,35 +56,48 @@ func (*FunctionLength) Name() string {
 	return "function-length"
 }
 
+// Metadata returns the description of the rule.
+func (*FunctionLength) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports functions with more statements or more lines than a maximum.",
+		Rationale:   "Long functions are hard to understand, splitting them makes them easier to read.",
+		Category:    "complexity",
+		Arguments: []lint.RuleArgument{
+			{Name: "maxStatements", Type: "integer", Description: "maximum number of statements, 50 by default, 0 disables the check"},
+			{Name: "maxLines", Type: "integer", Description: "maximum number of lines, 75 by default, 0 disables the check"},
+		},
+	}
+}
+
 const defaultFuncStmtsLimit = 50
 const defaultFuncLinesLimit = 75
 
-func (*FunctionLength) parseArguments(arguments lint.Arguments) (maxStmt, maxLines int64) {
+func (*FunctionLength) parseArguments(arguments lint.Arguments) (maxStmt, maxLines int64, err error) {
 	if len(arguments) == 0 {
-		return defaultFuncStmtsLimit, defaultFuncLinesLimit
+		return defaultFuncStmtsLimit, defaultFuncLinesLimit, nil
 	}
 
 	if len(arguments) != 2 {
-		panic(fmt.Sprintf(`invalid configuration for "function-length" rule, expected 2 arguments but got %d`, len(arguments)))
+		return 0, 0, fmt.Errorf(`invalid configuration for "function-length" rule, expected 2 arguments but got %d`, len(arguments))
 	}
 
 	maxStmt, maxStmtOk := arguments[0].(int64)
 	if !maxStmtOk {
-		panic(fmt.Sprintf(`invalid configuration value for max statements in "function-length" rule; need int64 but got %T`, arguments[0]))
+		return 0, 0, fmt.Errorf(`invalid configuration value for max statements in "function-length" rule; need int64 but got %T`, arguments[0])
 	}
 	if maxStmt < 0 {
-		panic(fmt.Sprintf(`the configuration value for max statements in "function-length" rule cannot be negative, got %d`, maxStmt))
+		return 0, 0, fmt.Errorf(`the configuration value for max statements in "function-length" rule cannot be negative, got %d`, maxStmt)
 	}
 
 	maxLines, maxLinesOk := arguments[1].(int64)
 	if !maxLinesOk {
-		panic(fmt.Sprintf(`invalid configuration value for max lines in "function-length" rule; need int64 but got %T`, arguments[1]))
+		return 0, 0, fmt.Errorf(`invalid configuration value for max lines in "function-length" rule; need int64 but got %T`, arguments[1])
 	}
 	if maxLines < 0 {
-		panic(fmt.Sprintf(`the configuration value for max statements in "function-length" rule cannot be negative, got %d`, maxLines))
+		return 0, 0, fmt.Errorf(`the configuration value for max lines in "function-length" rule cannot be negative, got %d`, maxLines)
 	}
 
-	return maxStmt, maxLines
+	return maxStmt, maxLines, nil
 }
 
 type lintFuncLength struct {
@@ -1,43 +1,46 @@
 package rule
 
 import (
+	"errors"
 	"fmt"
 	"go/ast"
-	"sync"
 
 	"github.com/mgechev/revive/lint"
 )
 
 // FunctionResultsLimitRule lints given else constructs.
 type FunctionResultsLimitRule struct {
+	configureOnce configureOnce
+
 	max int
-	sync.Mutex
 }
 
 const defaultResultsLimit = 3
 
-func (r *FunctionResultsLimitRule) configure(arguments lint.Arguments) {
-	r.Lock()
-	defer r.Unlock()
-	if r.max == 0 {
-		if len(arguments) < 1 {
-			r.max = defaultResultsLimit
-			return
-		}
-		max, ok := arguments[0].(int64) // Alt. non panicking version
-		if !ok {
-			panic(fmt.Sprintf(`invalid value passed as return results number to the "function-result-limit" rule; need int64 but got %T`, arguments[0]))
-		}
-		if max < 0 {
-			panic(`the value passed as return results number to the "function-result-limit" rule cannot be negative`)
-		}
-		r.max = int(max)
+// Configure validates the arguments of the rule and configures it.
+func (r *FunctionResultsLimitRule) Configure(arguments lint.Arguments) error {
+	return r.configureOnce.do(func() error { return r.configure(arguments) })
+}
+
+func (r *FunctionResultsLimitRule) configure(arguments lint.Arguments) error {
+	if len(arguments) < 1 {
+		r.max = defaultResultsLimit
+		return nil
 	}
+	max, ok := arguments[0].(int64)
+	if !ok {
+		return fmt.Errorf(`invalid value passed as return results number to the "function-result-limit" rule; need int64 but got %T`, arguments[0])
+	}
+	if max < 0 {
+		return errors.New(`the value passed as return results number to the "function-result-limit" rule cannot be negative`)
+	}
+	r.max = int(max)
+	return nil
 }
 
 // Apply applies the rule to given file.
 func (r *FunctionResultsLimitRule) Apply(file *lint.File, arguments lint.Arguments) []lint.Failure {
-	r.configure(arguments)
+	r.configureOnce.ensure(func() error { return r.configure(arguments) })
 
 	var failures []lint.Failure
 
@@ -58,6 +61,22 @@ func (*FunctionResultsLimitRule) Name() string {
 	return "function-result-limit"
 }
 
+// Metadata returns the description of the rule.
+func (*FunctionResultsLimitRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports functions with more results than a maximum.",
+		Rationale:   "A function with many results is hard to call, grouping them in a struct helps.",
+		Category:    "complexity",
+		Arguments: []lint.RuleArgument{
+			{Name: "max", Type: "integer", Description: "maximum number of results, 3 by default"},
+		},
+		Examples: []lint.RuleExample{{
+			Bad:  `func stats(values []int) (int, int, int, float64, error)`,
+			Good: `func stats(values []int) (Summary, error)`,
+		}},
+	}
+}
+
 type lintFunctionResultsNum struct {
 	max       int
 	onFailure func(lint.Failure)
@@ -29,6 +29,19 @@ func (*GetReturnRule) Name() string {
 	return "get-return"
 }
 
+// Metadata returns the description of the rule.
+func (*GetReturnRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports functions whose name starts with Get but that return nothing.",
+		Rationale:   "A getter is expected to return the value it gets.",
+		Category:    "logic",
+		Examples: []lint.RuleExample{{
+			Bad:  `func GetUser(id int)`,
+			Good: `func GetUser(id int) (*User, error)`,
+		}},
+	}
+}
+
 type lintReturnRule struct {
 	onFailure func(lint.Failure)
 }
@@ -28,6 +28,23 @@ func (*IdenticalBranchesRule) Name() string {
 	return "identical-branches"
 }
 
+// Metadata returns the description of the rule.
+func (*IdenticalBranchesRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports if-else statements whose branches are identical.",
+		Rationale:   "The condition is useless, or one of the branches is wrong.",
+		Category:    "logic",
+		Examples: []lint.RuleExample{{
+			Bad: `if debug {
+	log.Print(msg)
+} else {
+	log.Print(msg)
+}`,
+			Good: `log.Print(msg)`,
+		}},
+	}
+}
+
 type lintIdenticalBranches struct {
 	file      *ast.File
 	onFailure func(lint.Failure)
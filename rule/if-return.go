@@ -30,6 +30,22 @@ func (*IfReturnRule) Name() string {
 	return "if-return"
 }
 
+// Metadata returns the description of the rule.
+func (*IfReturnRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports checks of an error followed by its return, that can be replaced by returning the error directly.",
+		Rationale:   "Returning the error directly is shorter and has the same behavior.",
+		Category:    "style",
+		Examples: []lint.RuleExample{{
+			Bad: `if err := save(); err != nil {
+	return err
+}
+return nil`,
+			Good: `return save()`,
+		}},
+	}
+}
+
 type lintElseError struct {
 	file      *ast.File
 	onFailure func(lint.Failure)
@@ -3,61 +3,69 @@ package rule
 import (
 	"fmt"
 	"regexp"
-	"sync"
 
 	"github.com/mgechev/revive/lint"
 )
 
 // ImportAliasNamingRule lints import alias naming.
 type ImportAliasNamingRule struct {
-	configured  bool
+	configureOnce configureOnce
+
 	allowRegexp *regexp.Regexp
 	denyRegexp  *regexp.Regexp
-	sync.Mutex
 }
 
 const defaultImportAliasNamingAllowRule = "^[a-z][a-z0-9]{0,}$"
 
 var defaultImportAliasNamingAllowRegexp = regexp.MustCompile(defaultImportAliasNamingAllowRule)
 
-func (r *ImportAliasNamingRule) configure(arguments lint.Arguments) {
-	r.Lock()
-	defer r.Unlock()
-	if r.configured {
-		return
-	}
+// Configure validates the arguments of the rule and configures it.
+func (r *ImportAliasNamingRule) Configure(arguments lint.Arguments) error {
+	return r.configureOnce.do(func() error { return r.configure(arguments) })
+}
+
+func (r *ImportAliasNamingRule) configure(arguments lint.Arguments) error {
+	r.allowRegexp = nil
+	r.denyRegexp = nil
 
 	if len(arguments) == 0 {
 		r.allowRegexp = defaultImportAliasNamingAllowRegexp
-		return
+		return nil
 	}
 
 	switch namingRule := arguments[0].(type) {
 	case string:
-		r.setAllowRule(namingRule)
+		if err := r.setAllowRule(namingRule); err != nil {
+			return err
+		}
 	case map[string]any: // expecting map[string]string
 		for k, v := range namingRule {
+			var err error
 			switch k {
 			case "allowRegex":
-				r.setAllowRule(v)
+				err = r.setAllowRule(v)
 			case "denyRegex":
-				r.setDenyRule(v)
+				err = r.setDenyRule(v)
 			default:
-				panic(fmt.Sprintf("Invalid map key for 'import-alias-naming' rule. Expecting 'allowRegex' or 'denyRegex', got %v", k))
+				err = fmt.Errorf("invalid map key for 'import-alias-naming' rule. Expecting 'allowRegex' or 'denyRegex', got %v", k)
+			}
+			if err != nil {
+				return err
 			}
 		}
 	default:
-		panic(fmt.Sprintf("Invalid argument '%v' for 'import-alias-naming' rule. Expecting string or map[string]string, got %T", arguments[0], arguments[0]))
+		return fmt.Errorf("invalid argument '%v' for 'import-alias-naming' rule. Expecting string or map[string]string, got %T", arguments[0], arguments[0])
 	}
 
 	if r.allowRegexp == nil && r.denyRegexp == nil {
 		r.allowRegexp = defaultImportAliasNamingAllowRegexp
 	}
+	return nil
 }
 
 // Apply applies the rule to given file.
 func (r *ImportAliasNamingRule) Apply(file *lint.File, arguments lint.Arguments) []lint.Failure {
-	r.configure(arguments)
+	r.configureOnce.ensure(func() error { return r.configure(arguments) })
 
 	var failures []lint.Failure
 
@@ -99,28 +107,47 @@ func (*ImportAliasNamingRule) Name() string {
 	return "import-alias-naming"
 }
 
-func (r *ImportAliasNamingRule) setAllowRule(value any) {
+// Metadata returns the description of the rule.
+func (*ImportAliasNamingRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports import aliases not matching the allowed pattern, lowercase letters and digits by default, or matching the denied one.",
+		Rationale:   "Aliases follow the conventions of the package names.",
+		Category:    "imports",
+		Arguments: []lint.RuleArgument{
+			{Name: "allowRegex", Type: "string", Description: "regular expression the aliases must match, ^[a-z][a-z0-9]{0,}$ by default, given as a string or as [{ allowRegex = \"...\" }]"},
+			{Name: "denyRegex", Type: "string", Description: "regular expression the aliases must not match, given as [{ denyRegex = \"...\" }]"},
+		},
+		Examples: []lint.RuleExample{{
+			Bad:  `import string_utils "example.com/strings"`,
+			Good: `import stringutils "example.com/strings"`,
+		}},
+	}
+}
+
+func (r *ImportAliasNamingRule) setAllowRule(value any) error {
 	namingRule, ok := value.(string)
 	if !ok {
-		panic(fmt.Sprintf("Invalid argument '%v' for import-alias-naming allowRegexp rule. Expecting string, got %T", value, value))
+		return fmt.Errorf("invalid argument '%v' for import-alias-naming allowRegexp rule. Expecting string, got %T", value, value)
 	}
 
 	namingRuleRegexp, err := regexp.Compile(namingRule)
 	if err != nil {
-		panic(fmt.Sprintf("Invalid argument to the import-alias-naming allowRegexp rule. Expecting %q to be a valid regular expression, got: %v", namingRule, err))
+		return fmt.Errorf("invalid argument to the import-alias-naming allowRegexp rule. Expecting %q to be a valid regular expression, got: %w", namingRule, err)
 	}
 	r.allowRegexp = namingRuleRegexp
+	return nil
 }
 
-func (r *ImportAliasNamingRule) setDenyRule(value any) {
+func (r *ImportAliasNamingRule) setDenyRule(value any) error {
 	namingRule, ok := value.(string)
 	if !ok {
-		panic(fmt.Sprintf("Invalid argument '%v' for import-alias-naming denyRegexp rule. Expecting string, got %T", value, value))
+		return fmt.Errorf("invalid argument '%v' for import-alias-naming denyRegexp rule. Expecting string, got %T", value, value)
 	}
 
 	namingRuleRegexp, err := regexp.Compile(namingRule)
 	if err != nil {
-		panic(fmt.Sprintf("Invalid argument to the import-alias-naming denyRegexp rule. Expecting %q to be a valid regular expression, got: %v", namingRule, err))
+		return fmt.Errorf("invalid argument to the import-alias-naming denyRegexp rule. Expecting %q to be a valid regular expression, got: %w", namingRule, err)
 	}
 	r.denyRegexp = namingRuleRegexp
+	return nil
 }
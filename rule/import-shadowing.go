@@ -42,6 +42,23 @@ func (*ImportShadowingRule) Name() string {
 	return "import-shadowing"
 }
 
+// Metadata returns the description of the rule.
+func (*ImportShadowingRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports identifiers shadowing the name of an imported package.",
+		Rationale:   "The shadowed package cannot be used in the scope of the identifier, and the code is confusing.",
+		Category:    "naming",
+		Examples: []lint.RuleExample{{
+			Bad: `import "path"
+
+func open(path string) error`,
+			Good: `import "path"
+
+func open(name string) error`,
+		}},
+	}
+}
+
 func getName(imp *ast.ImportSpec) string {
 	const pathSep = "/"
 	const strDelim = `"`
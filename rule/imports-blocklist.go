@@ -3,38 +3,39 @@ package rule
 import (
 	"fmt"
 	"regexp"
-	"sync"
 
 	"github.com/mgechev/revive/lint"
 )
 
 // ImportsBlocklistRule lints given else constructs.
 type ImportsBlocklistRule struct {
+	configureOnce configureOnce
+
 	blocklist []*regexp.Regexp
-	sync.Mutex
 }
 
 var replaceImportRegexp = regexp.MustCompile(`/?\*\*/?`)
 
-func (r *ImportsBlocklistRule) configure(arguments lint.Arguments) {
-	r.Lock()
-	defer r.Unlock()
+// Configure validates the arguments of the rule and configures it.
+func (r *ImportsBlocklistRule) Configure(arguments lint.Arguments) error {
+	return r.configureOnce.do(func() error { return r.configure(arguments) })
+}
 
-	if r.blocklist == nil {
-		r.blocklist = make([]*regexp.Regexp, 0)
+func (r *ImportsBlocklistRule) configure(arguments lint.Arguments) error {
+	r.blocklist = make([]*regexp.Regexp, 0, len(arguments))
 
-		for _, arg := range arguments {
-			argStr, ok := arg.(string)
-			if !ok {
-				panic(fmt.Sprintf("Invalid argument to the imports-blocklist rule. Expecting a string, got %T", arg))
-			}
-			regStr, err := regexp.Compile(fmt.Sprintf(`(?m)"%s"$`, replaceImportRegexp.ReplaceAllString(argStr, `(\W|\w)*`)))
-			if err != nil {
-				panic(fmt.Sprintf("Invalid argument to the imports-blocklist rule. Expecting %q to be a valid regular expression, got: %v", argStr, err))
-			}
-			r.blocklist = append(r.blocklist, regStr)
+	for _, arg := range arguments {
+		argStr, ok := arg.(string)
+		if !ok {
+			return fmt.Errorf("invalid argument to the imports-blocklist rule. Expecting a string, got %T", arg)
+		}
+		regStr, err := regexp.Compile(fmt.Sprintf(`(?m)"%s"$`, replaceImportRegexp.ReplaceAllString(argStr, `(\W|\w)*`)))
+		if err != nil {
+			return fmt.Errorf("invalid argument to the imports-blocklist rule. Expecting %q to be a valid regular expression, got: %w", argStr, err)
 		}
+		r.blocklist = append(r.blocklist, regStr)
 	}
+	return nil
 }
 
 func (r *ImportsBlocklistRule) isBlocklisted(path string) bool {
@@ -48,7 +49,7 @@ func (r *ImportsBlocklistRule) isBlocklisted(path string) bool {
 
 // Apply applies the rule to given file.
 func (r *ImportsBlocklistRule) Apply(file *lint.File, arguments lint.Arguments) []lint.Failure {
-	r.configure(arguments)
+	r.configureOnce.ensure(func() error { return r.configure(arguments) })
 
 	var failures []lint.Failure
 
@@ -71,3 +72,19 @@ func (r *ImportsBlocklistRule) Apply(file *lint.File, arguments lint.Arguments)
 func (*ImportsBlocklistRule) Name() string {
 	return "imports-blocklist"
 }
+
+// Metadata returns the description of the rule.
+func (*ImportsBlocklistRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports imports of the block-listed packages.",
+		Rationale:   "Some packages are deprecated, insecure or replaced in a project.",
+		Category:    "imports",
+		Arguments: []lint.RuleArgument{
+			{Name: "packages", Type: "list of strings", Description: "import paths of the blocked packages, ** matching any part of a path, e.g. \"crypto/md5\""},
+		},
+		Examples: []lint.RuleExample{{
+			Bad:  `import "crypto/md5"`,
+			Good: `import "crypto/sha256"`,
+		}},
+	}
+}
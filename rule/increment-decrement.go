@@ -33,6 +33,20 @@ func (*IncrementDecrementRule) Name() string {
 	return "increment-decrement"
 }
 
+// Metadata returns the description of the rule.
+func (*IncrementDecrementRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description:    "Reports x += 1 and x -= 1 statements, that can be written x++ and x--.",
+		Rationale:      "The increment and decrement statements are the idiomatic way to add or subtract one.",
+		Category:       "style",
+		DefaultEnabled: true,
+		Examples: []lint.RuleExample{{
+			Bad:  "count += 1",
+			Good: "count++",
+		}},
+	}
+}
+
 type lintIncrementDecrement struct {
 	file      *lint.File
 	onFailure func(lint.Failure)
@@ -58,11 +72,13 @@ func (w lintIncrementDecrement) Visit(n ast.Node) ast.Visitor {
 	default:
 		return w
 	}
+	replacement := w.file.Render(as.Lhs[0]) + suffix
 	w.onFailure(lint.Failure{
 		Confidence: 0.8,
 		Node:       as,
 		Category:   "unary-op",
-		Failure:    fmt.Sprintf("should replace %s with %s%s", w.file.Render(as), w.file.Render(as.Lhs[0]), suffix),
+		Failure:    fmt.Sprintf("should replace %s with %s", w.file.Render(as), replacement),
+		Edits:      []lint.Edit{lint.ToEdit(as.Pos(), as.End(), replacement, w.file)},
 	})
 	return w
 }
@@ -10,7 +10,7 @@ type IndentErrorFlowRule struct{}
 
 // Apply applies the rule to given file.
 func (e *IndentErrorFlowRule) Apply(file *lint.File, args lint.Arguments) []lint.Failure {
-	return ifelse.Apply(e, file.AST, ifelse.TargetElse, args)
+	return ifelse.Apply(e, file, ifelse.TargetElse, args)
 }
 
 // Name returns the rule name.
@@ -18,6 +18,30 @@ func (*IndentErrorFlowRule) Name() string {
 	return "indent-error-flow"
 }
 
+// Metadata returns the description of the rule.
+func (*IndentErrorFlowRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description:    "Reports else blocks following an if block ending with a return statement, whose content can be outdented.",
+		Rationale:      "Handling errors in if blocks, and keeping the normal flow of the function at the minimal indentation, makes the function easier to read.",
+		Category:       "style",
+		DefaultEnabled: true,
+		Arguments: []lint.RuleArgument{
+			{Name: "flags", Type: "list of strings", Description: "preserveScope does not suggest refactorings that would increase the scope of variables"},
+		},
+		Examples: []lint.RuleExample{{
+			Bad: `if err != nil {
+	return err
+} else {
+	process(v)
+}`,
+			Good: `if err != nil {
+	return err
+}
+process(v)`,
+		}},
+	}
+}
+
 // CheckIfElse evaluates the rule against an ifelse.Chain.
 func (*IndentErrorFlowRule) CheckIfElse(chain ifelse.Chain, args ifelse.Args) (failMsg string) {
 	if !chain.If.Deviates() {
@@ -3,10 +3,10 @@ package rule
 import (
 	"bufio"
 	"bytes"
+	"errors"
 	"fmt"
 	"go/token"
 	"strings"
-	"sync"
 	"unicode/utf8"
 
 	"github.com/mgechev/revive/lint"
@@ -14,33 +14,36 @@ import (
 
 // LineLengthLimitRule lints given else constructs.
 type LineLengthLimitRule struct {
+	configureOnce configureOnce
+
 	max int
-	sync.Mutex
 }
 
 const defaultLineLengthLimit = 80
 
-func (r *LineLengthLimitRule) configure(arguments lint.Arguments) {
-	r.Lock()
-	defer r.Unlock()
-	if r.max == 0 {
-		if len(arguments) < 1 {
-			r.max = defaultLineLengthLimit
-			return
-		}
+// Configure validates the arguments of the rule and configures it.
+func (r *LineLengthLimitRule) Configure(arguments lint.Arguments) error {
+	return r.configureOnce.do(func() error { return r.configure(arguments) })
+}
 
-		max, ok := arguments[0].(int64) // Alt. non panicking version
-		if !ok || max < 0 {
-			panic(`invalid value passed as argument number to the "line-length-limit" rule`)
-		}
+func (r *LineLengthLimitRule) configure(arguments lint.Arguments) error {
+	if len(arguments) < 1 {
+		r.max = defaultLineLengthLimit
+		return nil
+	}
 
-		r.max = int(max)
+	max, ok := arguments[0].(int64)
+	if !ok || max < 0 {
+		return errors.New(`invalid value passed as argument number to the "line-length-limit" rule`)
 	}
+
+	r.max = int(max)
+	return nil
 }
 
 // Apply applies the rule to given file.
 func (r *LineLengthLimitRule) Apply(file *lint.File, arguments lint.Arguments) []lint.Failure {
-	r.configure(arguments)
+	r.configureOnce.ensure(func() error { return r.configure(arguments) })
 
 	var failures []lint.Failure
 
@@ -62,6 +65,18 @@ func (*LineLengthLimitRule) Name() string {
 	return "line-length-limit"
 }
 
+// Metadata returns the description of the rule.
+func (*LineLengthLimitRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports lines longer than a maximum number of characters.",
+		Rationale:   "Long lines are hard to read, especially side by side in diffs.",
+		Category:    "style",
+		Arguments: []lint.RuleArgument{
+			{Name: "max", Type: "integer", Description: "maximum number of characters of a line, 80 by default"},
+		},
+	}
+}
+
 type lintLineLengthNum struct {
 	max       int
 	file      *lint.File
@@ -1,24 +1,25 @@
 package rule
 
 import (
+	"errors"
 	"fmt"
 	"go/ast"
-	"sync"
 
 	"github.com/mgechev/revive/lint"
 )
 
 // MaxControlNestingRule lints given else constructs.
 type MaxControlNestingRule struct {
+	configureOnce configureOnce
+
 	max int64
-	sync.Mutex
 }
 
 const defaultMaxControlNesting = 5
 
 // Apply applies the rule to given file.
 func (r *MaxControlNestingRule) Apply(file *lint.File, arguments lint.Arguments) []lint.Failure {
-	r.configure(arguments)
+	r.configureOnce.ensure(func() error { return r.configure(arguments) })
 
 	var failures []lint.Failure
 
@@ -41,6 +42,18 @@ func (*MaxControlNestingRule) Name() string {
 	return "max-control-nesting"
 }
 
+// Metadata returns the description of the rule.
+func (*MaxControlNestingRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports control structures (if, for, switch and select) nested deeper than a maximum.",
+		Rationale:   "Deeply nested code is hard to follow, returning early or extracting functions reduces the nesting.",
+		Category:    "complexity",
+		Arguments: []lint.RuleArgument{
+			{Name: "max", Type: "integer", Description: "maximum nesting level, 5 by default"},
+		},
+	}
+}
+
 type lintMaxControlNesting struct {
 	max             int
 	onFailure       func(lint.Failure)
@@ -106,23 +119,25 @@ func (w *lintMaxControlNesting) walkControlledBlock(b ast.Node) {
 	w.nestingLevelAcc = oldNestingLevel
 }
 
-func (r *MaxControlNestingRule) configure(arguments lint.Arguments) {
-	r.Lock()
-	defer r.Unlock()
-	if !(r.max < 1) {
-		return // max already set
-	}
+// Configure validates the arguments of the rule and configures it.
+func (r *MaxControlNestingRule) Configure(arguments lint.Arguments) error {
+	return r.configureOnce.do(func() error { return r.configure(arguments) })
+}
 
+func (r *MaxControlNestingRule) configure(arguments lint.Arguments) error {
 	if len(arguments) < 1 {
 		r.max = defaultMaxControlNesting
-		return
+		return nil
 	}
 
-	checkNumberOfArguments(1, arguments, r.Name())
+	if err := checkNumberOfArguments(1, arguments, r.Name()); err != nil {
+		return err
+	}
 
-	max, ok := arguments[0].(int64) // Alt. non panicking version
+	max, ok := arguments[0].(int64)
 	if !ok {
-		panic(`invalid value passed as argument number to the "max-control-nesting" rule`)
+		return errors.New(`invalid value passed as argument number to the "max-control-nesting" rule`)
 	}
 	r.max = max
+	return nil
 }
@@ -1,43 +1,48 @@
 package rule
 
 import (
+	"errors"
 	"go/ast"
 	"strings"
-	"sync"
 
 	"github.com/mgechev/revive/lint"
 )
 
 // MaxPublicStructsRule lints given else constructs.
 type MaxPublicStructsRule struct {
+	configureOnce configureOnce
+
 	max int64
-	sync.Mutex
 }
 
 const defaultMaxPublicStructs = 5
 
-func (r *MaxPublicStructsRule) configure(arguments lint.Arguments) {
-	r.Lock()
-	defer r.Unlock()
-	if r.max < 1 {
-		if len(arguments) < 1 {
-			r.max = defaultMaxPublicStructs
-			return
-		}
+// Configure validates the arguments of the rule and configures it.
+func (r *MaxPublicStructsRule) Configure(arguments lint.Arguments) error {
+	return r.configureOnce.do(func() error { return r.configure(arguments) })
+}
 
-		checkNumberOfArguments(1, arguments, r.Name())
+func (r *MaxPublicStructsRule) configure(arguments lint.Arguments) error {
+	if len(arguments) < 1 {
+		r.max = defaultMaxPublicStructs
+		return nil
+	}
 
-		max, ok := arguments[0].(int64) // Alt. non panicking version
-		if !ok {
-			panic(`invalid value passed as argument number to the "max-public-structs" rule`)
-		}
-		r.max = max
+	if err := checkNumberOfArguments(1, arguments, r.Name()); err != nil {
+		return err
 	}
+
+	max, ok := arguments[0].(int64)
+	if !ok {
+		return errors.New(`invalid value passed as argument number to the "max-public-structs" rule`)
+	}
+	r.max = max
+	return nil
 }
 
 // Apply applies the rule to given file.
 func (r *MaxPublicStructsRule) Apply(file *lint.File, arguments lint.Arguments) []lint.Failure {
-	r.configure(arguments)
+	r.configureOnce.ensure(func() error { return r.configure(arguments) })
 
 	var failures []lint.Failure
 
@@ -69,6 +74,18 @@ func (*MaxPublicStructsRule) Name() string {
 	return "max-public-structs"
 }
 
+// Metadata returns the description of the rule.
+func (*MaxPublicStructsRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports files declaring more exported structs than a maximum.",
+		Rationale:   "Many exported structs make a package hard to understand, and may be the sign of a bad design.",
+		Category:    "complexity",
+		Arguments: []lint.RuleArgument{
+			{Name: "max", Type: "integer", Description: "maximum number of exported structs of a file, 5 by default"},
+		},
+	}
+}
+
 type lintMaxPublicStructs struct {
 	current   int64
 	fileAst   *ast.File
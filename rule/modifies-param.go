@@ -28,6 +28,24 @@ func (*ModifiesParamRule) Name() string {
 	return "modifies-parameter"
 }
 
+// Metadata returns the description of the rule.
+func (*ModifiesParamRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports assignments to the parameters of functions.",
+		Rationale:   "Parameters are copies of the arguments: modifying them does not affect the caller, which is misleading.",
+		Category:    "bugs",
+		Examples: []lint.RuleExample{{
+			Bad: `func normalize(name string) string {
+	name = strings.ToLower(name)
+	return name
+}`,
+			Good: `func normalize(name string) string {
+	return strings.ToLower(name)
+}`,
+		}},
+	}
+}
+
 type lintModifiesParamRule struct {
 	params    map[string]bool
 	onFailure func(lint.Failure)
@@ -30,6 +30,23 @@ func (*ModifiesValRecRule) Name() string {
 	return "modifies-value-receiver"
 }
 
+// Metadata returns the description of the rule.
+func (*ModifiesValRecRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports methods with a value receiver modifying it.",
+		Rationale:   "The receiver is a copy of the value the method is called on, the modification is lost when the method returns.",
+		Category:    "bugs",
+		Examples: []lint.RuleExample{{
+			Bad: `func (c Counter) Reset() {
+	c.count = 0
+}`,
+			Good: `func (c *Counter) Reset() {
+	c.count = 0
+}`,
+		}},
+	}
+}
+
 type lintModifiesValRecRule struct {
 	file      *lint.File
 	onFailure func(lint.Failure)
@@ -29,6 +29,29 @@ func (*NestedStructs) Name() string {
 	return "nested-structs"
 }
 
+// Metadata returns the description of the rule.
+func (*NestedStructs) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports struct types declared inside other structs.",
+		Rationale:   "Nested struct types are hard to read, and their values hard to create, a named type is clearer.",
+		Category:    "style",
+		Examples: []lint.RuleExample{{
+			Bad: `type Config struct {
+	Server struct {
+		Port int
+	}
+}`,
+			Good: `type ServerConfig struct {
+	Port int
+}
+
+type Config struct {
+	Server ServerConfig
+}`,
+		}},
+	}
+}
+
 type lintNestedStructs struct {
 	onFailure func(lint.Failure)
 }
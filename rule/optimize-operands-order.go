@@ -30,6 +30,19 @@ func (*OptimizeOperandsOrderRule) Name() string {
 	return "optimize-operands-order"
 }
 
+// Metadata returns the description of the rule.
+func (*OptimizeOperandsOrderRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports logical expressions evaluating a function call before a cheaper operand, that could short-circuit it.",
+		Rationale:   "Evaluating the cheap operands first avoids the calls when they decide the result. The order may matter: check the suggestion keeps the behavior.",
+		Category:    "performance",
+		Examples: []lint.RuleExample{{
+			Bad:  `if isGenerated(content) && !ignoreGenerated {`,
+			Good: `if !ignoreGenerated && isGenerated(content) {`,
+		}},
+	}
+}
+
 type lintOptimizeOperandsOrderlExpr struct {
 	onFailure func(failure lint.Failure)
 }
@@ -42,6 +42,22 @@ func (*PackageCommentsRule) Name() string {
 	return "package-comments"
 }
 
+// Metadata returns the description of the rule.
+func (*PackageCommentsRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description:    "Reports packages without a package comment, and package comments not of the form \"Package x ...\".",
+		Rationale:      "The package comment introduces the package in its documentation.",
+		Category:       "comments",
+		DefaultEnabled: true,
+		Examples: []lint.RuleExample{{
+			Bad: `// Utilities for strings.
+package strutil`,
+			Good: `// Package strutil provides utilities for strings.
+package strutil`,
+		}},
+	}
+}
+
 type lintPackageComments struct {
 	fileAst   *ast.File
 	file      *lint.File
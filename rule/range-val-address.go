@@ -38,6 +38,23 @@ func (*RangeValAddress) Name() string {
 	return "range-val-address"
 }
 
+// Metadata returns the description of the rule.
+func (*RangeValAddress) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports the addresses of range values taken in a loop and stored, e.g. appended to a slice or put in a map.",
+		Rationale:   "Before Go 1.22, the range value is a single variable reused by all the iterations: all the stored addresses are the same.",
+		Category:    "bugs",
+		Examples: []lint.RuleExample{{
+			Bad: `for _, user := range users {
+	pointers = append(pointers, &user)
+}`,
+			Good: `for i := range users {
+	pointers = append(pointers, &users[i])
+}`,
+		}},
+	}
+}
+
 type rangeValAddress struct {
 	file      *lint.File
 	onFailure func(lint.Failure)
@@ -34,6 +34,27 @@ func (*RangeValInClosureRule) Name() string {
 	return "range-val-in-closure"
 }
 
+// Metadata returns the description of the rule.
+func (*RangeValInClosureRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports range values used by goroutines or deferred functions started in a loop.",
+		Rationale:   "Before Go 1.22, the range value is a single variable reused by all the iterations: the closures may all see its last value.",
+		Category:    "bugs",
+		Examples: []lint.RuleExample{{
+			Bad: `for _, job := range jobs {
+	go func() {
+		run(job)
+	}()
+}`,
+			Good: `for _, job := range jobs {
+	go func(job Job) {
+		run(job)
+	}(job)
+}`,
+		}},
+	}
+}
+
 type rangeValInClosure struct {
 	onFailure func(lint.Failure)
 }
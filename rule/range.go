@@ -29,6 +29,24 @@ func (*RangeRule) Name() string {
 	return "range"
 }
 
+// Metadata returns the description of the rule.
+func (*RangeRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description:    "Reports range loops whose second value is blank and can be omitted.",
+		Rationale:      "for k := range m is equivalent to, and shorter than, for k, _ := range m.",
+		Category:       "style",
+		DefaultEnabled: true,
+		Examples: []lint.RuleExample{{
+			Bad: `for i, _ := range items {
+	print(i)
+}`,
+			Good: `for i := range items {
+	print(i)
+}`,
+		}},
+	}
+}
+
 type lintRanges struct {
 	file      *lint.File
 	onFailure func(lint.Failure)
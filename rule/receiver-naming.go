@@ -33,6 +33,22 @@ func (*ReceiverNamingRule) Name() string {
 	return "receiver-naming"
 }
 
+// Metadata returns the description of the rule.
+func (*ReceiverNamingRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description:    "Reports receivers named this, self or _, and receivers named differently in the methods of a type.",
+		Rationale:      "The receiver is a parameter like any other: its name is a short reflection of its type, consistent across the methods.",
+		Category:       "naming",
+		DefaultEnabled: true,
+		Examples: []lint.RuleExample{{
+			Bad: `func (this *Server) Start() {}
+func (srv *Server) Stop()  {}`,
+			Good: `func (s *Server) Start() {}
+func (s *Server) Stop()  {}`,
+		}},
+	}
+}
+
 type lintReceiverName struct {
 	onFailure    func(lint.Failure)
 	typeReceiver map[string]string
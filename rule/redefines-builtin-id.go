@@ -97,6 +97,20 @@ func (*RedefinesBuiltinIDRule) Name() string {
 	return "redefines-builtin-id"
 }
 
+// Metadata returns the description of the rule.
+func (*RedefinesBuiltinIDRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description:    "Reports declarations redefining a builtin identifier, such as len or error.",
+		Rationale:      "A redefined builtin is shadowed in its scope, which is confusing and a source of bugs.",
+		Category:       "logic",
+		DefaultEnabled: true,
+		Examples: []lint.RuleExample{{
+			Bad:  "copy := append([]int{}, values...)",
+			Good: "clone := append([]int{}, values...)",
+		}},
+	}
+}
+
 type lintRedefinesBuiltinID struct {
 	onFailure           func(lint.Failure)
 	builtInConstAndVars map[string]bool
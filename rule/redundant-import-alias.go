@@ -26,6 +26,7 @@ func (*RedundantImportAlias) Apply(file *lint.File, _ lint.Arguments) []lint.Fai
 				Failure:    fmt.Sprintf("Import alias \"%s\" is redundant", imp.Name.Name),
 				Node:       imp,
 				Category:   "imports",
+				Edits:      []lint.Edit{lint.ToEdit(imp.Name.Pos(), imp.Path.Pos(), "", file)},
 			})
 		}
 	}
@@ -38,6 +39,19 @@ func (*RedundantImportAlias) Name() string {
 	return "redundant-import-alias"
 }
 
+// Metadata returns the description of the rule.
+func (*RedundantImportAlias) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports import aliases equal to the name of the imported package.",
+		Rationale:   "The alias is useless, the package has this name anyway.",
+		Category:    "imports",
+		Examples: []lint.RuleExample{{
+			Bad:  `import strings "strings"`,
+			Good: `import "strings"`,
+		}},
+	}
+}
+
 func getImportPackageName(imp *ast.ImportSpec) string {
 	const pathSep = "/"
 	const strDelim = `"`
@@ -35,6 +35,29 @@ func (*ResourceLeakRule) Name() string {
 	return "resource-leak"
 }
 
+// Metadata returns the description of the rule.
+func (*ResourceLeakRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description:    "Reports resources, such as files or connections, opened in a function without being closed there.",
+		Rationale:      "A resource that is not closed leaks its file descriptor or its connection.",
+		Category:       "bugs",
+		DefaultEnabled: true,
+		Examples: []lint.RuleExample{{
+			Bad: `f, err := os.Open(path)
+if err != nil {
+	return err
+}
+return process(f)`,
+			Good: `f, err := os.Open(path)
+if err != nil {
+	return err
+}
+defer f.Close()
+return process(f)`,
+		}},
+	}
+}
+
 type lintResourceLeak struct {
 	file      *lint.File
 	fileAst   *ast.File
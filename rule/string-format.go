@@ -14,18 +14,42 @@ import (
 // #region Revive API
 
 // StringFormatRule lints strings and/or comments according to a set of regular expressions given as Arguments
-type StringFormatRule struct{}
+type StringFormatRule struct {
+	configureOnce configureOnce
+
+	rules []stringFormatSubrule
+}
+
+// Configure validates the arguments of the rule and configures it.
+func (r *StringFormatRule) Configure(arguments lint.Arguments) error {
+	return r.configureOnce.do(func() error { return r.configure(arguments) })
+}
+
+func (r *StringFormatRule) configure(arguments lint.Arguments) error {
+	w := lintStringFormatRule{}
+	if err := w.parseArguments(arguments); err != nil {
+		return err
+	}
+	r.rules = w.rules
+	return nil
+}
 
 // Apply applies the rule to the given file.
-func (*StringFormatRule) Apply(file *lint.File, arguments lint.Arguments) []lint.Failure {
+func (r *StringFormatRule) Apply(file *lint.File, arguments lint.Arguments) []lint.Failure {
+	r.configureOnce.ensure(func() error { return r.configure(arguments) })
+
 	var failures []lint.Failure
 
 	onFailure := func(failure lint.Failure) {
 		failures = append(failures, failure)
 	}
 
-	w := lintStringFormatRule{onFailure: onFailure}
-	w.parseArguments(arguments)
+	w := &lintStringFormatRule{onFailure: onFailure}
+	w.rules = make([]stringFormatSubrule, len(r.rules))
+	for i, rule := range r.rules {
+		rule.parent = w
+		w.rules[i] = rule
+	}
 	ast.Walk(w, file.AST)
 
 	return failures
@@ -36,21 +60,24 @@ func (*StringFormatRule) Name() string {
 	return "string-format"
 }
 
-// ParseArgumentsTest is a public wrapper around w.parseArguments used for testing. Returns the error message provided to panic, or nil if no error was encountered
-func (StringFormatRule) ParseArgumentsTest(arguments lint.Arguments) *string {
+// Metadata returns the description of the rule.
+func (*StringFormatRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports the string literals passed to the configured functions that do not match the configured regular expressions.",
+		Rationale:   "The messages of an application, such as those shown to its users, are formatted consistently.",
+		Category:    "style",
+		Arguments: []lint.RuleArgument{
+			{Name: "formats", Type: "lists of strings", Description: "each argument is a scope, e.g. \"fmt.Errorf[0]\" or \"core.WriteError[1].Message\", a regular expression between slashes, prefixed with ! to reject the matching strings, and an optional message"},
+		},
+	}
+}
+
+// ParseArgumentsTest is a public wrapper around w.parseArguments used for testing. Returns the error message, or nil if no error was encountered
+func (*StringFormatRule) ParseArgumentsTest(arguments lint.Arguments) *string {
 	w := lintStringFormatRule{}
-	c := make(chan any)
-	// Parse the arguments in a goroutine, defer a recover() call, return the error encountered (or nil if there was no error)
-	go func() {
-		defer func() {
-			err := recover()
-			c <- err
-		}()
-		w.parseArguments(arguments)
-	}()
-	err := <-c
+	err := w.parseArguments(arguments)
 	if err != nil {
-		e := fmt.Sprintf("%s", err)
+		e := err.Error()
 		return &e
 	}
 	return nil
@@ -91,9 +118,12 @@ var parseStringFormatScope = regexp.MustCompile(
 
 // #region Argument parsing
 
-func (w *lintStringFormatRule) parseArguments(arguments lint.Arguments) {
+func (w *lintStringFormatRule) parseArguments(arguments lint.Arguments) error {
 	for i, argument := range arguments {
-		scopes, regex, negated, errorMessage := w.parseArgument(argument, i)
+		scopes, regex, negated, errorMessage, err := w.parseArgument(argument, i)
+		if err != nil {
+			return err
+		}
 		w.rules = append(w.rules, stringFormatSubrule{
 			parent:       w,
 			scopes:       scopes,
@@ -102,30 +132,32 @@ func (w *lintStringFormatRule) parseArguments(arguments lint.Arguments) {
 			errorMessage: errorMessage,
 		})
 	}
+	return nil
 }
 
-func (w lintStringFormatRule) parseArgument(argument any, ruleNum int) (scopes stringFormatSubruleScopes, regex *regexp.Regexp, negated bool, errorMessage string) {
+func (w lintStringFormatRule) parseArgument(argument any, ruleNum int) (scopes stringFormatSubruleScopes, regex *regexp.Regexp, negated bool, errorMessage string, err error) {
 	g, ok := argument.([]any) // Cast to generic slice first
 	if !ok {
-		w.configError("argument is not a slice", ruleNum, 0)
+		return nil, nil, false, "", w.configError("argument is not a slice", ruleNum, 0)
 	}
 	if len(g) < 2 {
-		w.configError("less than two slices found in argument, scope and regex are required", ruleNum, len(g)-1)
+		return nil, nil, false, "", w.configError("less than two slices found in argument, scope and regex are required", ruleNum, len(g)-1)
 	}
 	rule := make([]string, len(g))
 	for i, obj := range g {
 		val, ok := obj.(string)
 		if !ok {
-			w.configError("unexpected value, string was expected", ruleNum, i)
+			return nil, nil, false, "", w.configError("unexpected value, string was expected", ruleNum, i)
 		}
 		rule[i] = val
 	}
 
 	// Validate scope and regex length
 	if rule[0] == "" {
-		w.configError("empty scope provided", ruleNum, 0)
-	} else if len(rule[1]) < 2 {
-		w.configError("regex is too small (regexes should begin and end with '/')", ruleNum, 1)
+		return nil, nil, false, "", w.configError("empty scope provided", ruleNum, 0)
+	}
+	if len(rule[1]) < 2 {
+		return nil, nil, false, "", w.configError("regex is too small (regexes should begin and end with '/')", ruleNum, 1)
 	}
 
 	// Parse rule scopes
@@ -136,24 +168,24 @@ func (w lintStringFormatRule) parseArgument(argument any, ruleNum int) (scopes s
 		rawScope = strings.TrimSpace(rawScope)
 
 		if len(rawScope) == 0 {
-			w.parseScopeError("empty scope in rule scopes:", ruleNum, 0, scopeNum)
+			return nil, nil, false, "", w.parseScopeError("empty scope in rule scopes:", ruleNum, 0, scopeNum)
 		}
 
 		scope := stringFormatSubruleScope{}
 		matches := parseStringFormatScope.FindStringSubmatch(rawScope)
 		if matches == nil {
 			// The rule's scope didn't match the parsing regex at all, probably a configuration error
-			w.parseScopeError("unable to parse rule scope", ruleNum, 0, scopeNum)
-		} else if len(matches) != 4 {
+			return nil, nil, false, "", w.parseScopeError("unable to parse rule scope", ruleNum, 0, scopeNum)
+		}
+		if len(matches) != 4 {
 			// The rule's scope matched the parsing regex, but an unexpected number of submatches was returned, probably a bug
-			w.parseScopeError(fmt.Sprintf("unexpected number of submatches when parsing scope: %d, expected 4", len(matches)), ruleNum, 0, scopeNum)
+			return nil, nil, false, "", w.parseScopeError(fmt.Sprintf("unexpected number of submatches when parsing scope: %d, expected 4", len(matches)), ruleNum, 0, scopeNum)
 		}
 		scope.funcName = matches[1]
 		if len(matches[2]) > 0 {
-			var err error
 			scope.argument, err = strconv.Atoi(matches[2])
 			if err != nil {
-				w.parseScopeError("unable to parse argument number in rule scope", ruleNum, 0, scopeNum)
+				return nil, nil, false, "", w.parseScopeError("unable to parse argument number in rule scope", ruleNum, 0, scopeNum)
 			}
 		}
 		if len(matches[3]) > 0 {
@@ -169,31 +201,31 @@ func (w lintStringFormatRule) parseArgument(argument any, ruleNum int) (scopes s
 	if negated {
 		offset++
 	}
-	regex, err := regexp.Compile(rule[1][offset : len(rule[1])-1])
+	regex, err = regexp.Compile(rule[1][offset : len(rule[1])-1])
 	if err != nil {
-		w.parseError(fmt.Sprintf("unable to compile %s as regexp", rule[1]), ruleNum, 1)
+		return nil, nil, false, "", w.parseError(fmt.Sprintf("unable to compile %s as regexp", rule[1]), ruleNum, 1)
 	}
 
 	// Use custom error message if provided
 	if len(rule) == 3 {
 		errorMessage = rule[2]
 	}
-	return scopes, regex, negated, errorMessage
+	return scopes, regex, negated, errorMessage, nil
 }
 
 // Report an invalid config, this is specifically the user's fault
-func (lintStringFormatRule) configError(msg string, ruleNum, option int) {
-	panic(fmt.Sprintf("invalid configuration for string-format: %s [argument %d, option %d]", msg, ruleNum, option))
+func (lintStringFormatRule) configError(msg string, ruleNum, option int) error {
+	return fmt.Errorf("invalid configuration for string-format: %s [argument %d, option %d]", msg, ruleNum, option)
 }
 
 // Report a general config parsing failure, this may be the user's fault, but it isn't known for certain
-func (lintStringFormatRule) parseError(msg string, ruleNum, option int) {
-	panic(fmt.Sprintf("failed to parse configuration for string-format: %s [argument %d, option %d]", msg, ruleNum, option))
+func (lintStringFormatRule) parseError(msg string, ruleNum, option int) error {
+	return fmt.Errorf("failed to parse configuration for string-format: %s [argument %d, option %d]", msg, ruleNum, option)
 }
 
 // Report a general scope config parsing failure, this may be the user's fault, but it isn't known for certain
-func (lintStringFormatRule) parseScopeError(msg string, ruleNum, option, scopeNum int) {
-	panic(fmt.Sprintf("failed to parse configuration for string-format: %s [argument %d, option %d, scope index %d]", msg, ruleNum, option, scopeNum))
+func (lintStringFormatRule) parseScopeError(msg string, ruleNum, option, scopeNum int) error {
+	return fmt.Errorf("failed to parse configuration for string-format: %s [argument %d, option %d, scope index %d]", msg, ruleNum, option, scopeNum)
 }
 
 // #endregion
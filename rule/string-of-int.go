@@ -32,6 +32,19 @@ func (*StringOfIntRule) Name() string {
 	return "string-of-int"
 }
 
+// Metadata returns the description of the rule.
+func (*StringOfIntRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports conversions of integers other than runes to strings.",
+		Rationale:   "string(i) returns the character of code point i, not the decimal representation of i.",
+		Category:    "bugs",
+		Examples: []lint.RuleExample{{
+			Bad:  `s := string(n)`,
+			Good: `s := strconv.Itoa(n)`,
+		}},
+	}
+}
+
 type lintStringInt struct {
 	file      *lint.File
 	onFailure func(lint.Failure)
@@ -5,7 +5,6 @@ import (
 	"go/ast"
 	"strconv"
 	"strings"
-	"sync"
 
 	"github.com/fatih/structtag"
 	"github.com/mgechev/revive/lint"
@@ -13,37 +12,47 @@ import (
 
 // StructTagRule lints struct tags.
 type StructTagRule struct {
+	configureOnce configureOnce
+
 	userDefined map[string][]string // map: key -> []option
-	sync.Mutex
 }
 
-func (r *StructTagRule) configure(arguments lint.Arguments) {
-	r.Lock()
-	defer r.Unlock()
-	if r.userDefined == nil && len(arguments) > 0 {
-		checkNumberOfArguments(1, arguments, r.Name())
-		r.userDefined = make(map[string][]string, len(arguments))
-		for _, arg := range arguments {
-			item, ok := arg.(string)
-			if !ok {
-				panic(fmt.Sprintf("Invalid argument to the %s rule. Expecting a string, got %v (of type %T)", r.Name(), arg, arg))
-			}
-			parts := strings.Split(item, ",")
-			if len(parts) < 2 {
-				panic(fmt.Sprintf("Invalid argument to the %s rule. Expecting a string of the form key[,option]+, got %s", r.Name(), item))
-			}
-			key := strings.TrimSpace(parts[0])
-			for i := 1; i < len(parts); i++ {
-				option := strings.TrimSpace(parts[i])
-				r.userDefined[key] = append(r.userDefined[key], option)
-			}
+// Configure validates the arguments of the rule and configures it.
+func (r *StructTagRule) Configure(arguments lint.Arguments) error {
+	return r.configureOnce.do(func() error { return r.configure(arguments) })
+}
+
+func (r *StructTagRule) configure(arguments lint.Arguments) error {
+	r.userDefined = nil
+	if len(arguments) == 0 {
+		return nil
+	}
+
+	if err := checkNumberOfArguments(1, arguments, r.Name()); err != nil {
+		return err
+	}
+	r.userDefined = make(map[string][]string, len(arguments))
+	for _, arg := range arguments {
+		item, ok := arg.(string)
+		if !ok {
+			return fmt.Errorf("invalid argument to the %s rule. Expecting a string, got %v (of type %T)", r.Name(), arg, arg)
+		}
+		parts := strings.Split(item, ",")
+		if len(parts) < 2 {
+			return fmt.Errorf("invalid argument to the %s rule. Expecting a string of the form key[,option]+, got %s", r.Name(), item)
+		}
+		key := strings.TrimSpace(parts[0])
+		for i := 1; i < len(parts); i++ {
+			option := strings.TrimSpace(parts[i])
+			r.userDefined[key] = append(r.userDefined[key], option)
 		}
 	}
+	return nil
 }
 
 // Apply applies the rule to given file.
-func (r *StructTagRule) Apply(file *lint.File, args lint.Arguments) []lint.Failure {
-	r.configure(args)
+func (r *StructTagRule) Apply(file *lint.File, arguments lint.Arguments) []lint.Failure {
+	r.configureOnce.ensure(func() error { return r.configure(arguments) })
 
 	var failures []lint.Failure
 	onFailure := func(failure lint.Failure) {
@@ -65,6 +74,22 @@ func (*StructTagRule) Name() string {
 	return "struct-tag"
 }
 
+// Metadata returns the description of the rule.
+func (*StructTagRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports malformed struct tags and invalid options of the common tags, such as json, xml, yaml, asn1, bson, protobuf and default.",
+		Rationale:   "Struct tags are not checked by the compiler, a mistake is silently ignored by the packages reading them.",
+		Category:    "bugs",
+		Arguments: []lint.RuleArgument{
+			{Name: "options", Type: "list of strings", Description: "additional options allowed for a tag, as the tag key followed by the options, comma-separated, e.g. \"json,inline\""},
+		},
+		Examples: []lint.RuleExample{{
+			Bad:  "Name string `json:\"name,omitempy\"`",
+			Good: "Name string `json:\"name,omitempty\"`",
+		}},
+	}
+}
+
 type lintStructTagRule struct {
 	onFailure   func(lint.Failure)
 	userDefined map[string][]string // map: key -> []option
@@ -11,7 +11,7 @@ type SuperfluousElseRule struct{}
 
 // Apply applies the rule to given file.
 func (e *SuperfluousElseRule) Apply(file *lint.File, args lint.Arguments) []lint.Failure {
-	return ifelse.Apply(e, file.AST, ifelse.TargetElse, args)
+	return ifelse.Apply(e, file, ifelse.TargetElse, args)
 }
 
 // Name returns the rule name.
@@ -19,6 +19,34 @@ func (*SuperfluousElseRule) Name() string {
 	return "superfluous-else"
 }
 
+// Metadata returns the description of the rule.
+func (*SuperfluousElseRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description:    "Reports else blocks following an if block ending with continue, break, goto or a call to a function that does not return, such as os.Exit, whose content can be outdented.",
+		Rationale:      "Keeping the normal flow at the minimal indentation makes the code easier to read.",
+		Category:       "style",
+		DefaultEnabled: true,
+		Arguments: []lint.RuleArgument{
+			{Name: "flags", Type: "list of strings", Description: "preserveScope does not suggest refactorings that would increase the scope of variables"},
+		},
+		Examples: []lint.RuleExample{{
+			Bad: `for _, v := range values {
+	if v < 0 {
+		continue
+	} else {
+		sum += v
+	}
+}`,
+			Good: `for _, v := range values {
+	if v < 0 {
+		continue
+	}
+	sum += v
+}`,
+		}},
+	}
+}
+
 // CheckIfElse evaluates the rule against an ifelse.Chain.
 func (*SuperfluousElseRule) CheckIfElse(chain ifelse.Chain, args ifelse.Args) (failMsg string) {
 	if !chain.If.Deviates() {
@@ -33,6 +33,19 @@ func (*TimeEqualRule) Name() string {
 	return "time-equal"
 }
 
+// Metadata returns the description of the rule.
+func (*TimeEqualRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports comparisons of time.Time values with == and !=.",
+		Rationale:   "The operators compare the location and the monotonic clock reading too, the Equal method compares the instants only.",
+		Category:    "bugs",
+		Examples: []lint.RuleExample{{
+			Bad:  `if deadline == now {`,
+			Good: `if deadline.Equal(now) {`,
+		}},
+	}
+}
+
 type lintTimeEqual struct {
 	file      *lint.File
 	onFailure func(lint.Failure)
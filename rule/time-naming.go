@@ -32,6 +32,20 @@ func (*TimeNamingRule) Name() string {
 	return "time-naming"
 }
 
+// Metadata returns the description of the rule.
+func (*TimeNamingRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description:    "Reports time.Duration variables named with a unit suffix, such as Secs or Ms.",
+		Rationale:      "A time.Duration holds its own unit: the suffix is redundant, and misleading once the value changes.",
+		Category:       "naming",
+		DefaultEnabled: true,
+		Examples: []lint.RuleExample{{
+			Bad:  "var timeoutSecs = 5 * time.Second",
+			Good: "var timeout = 5 * time.Second",
+		}},
+	}
+}
+
 type lintTimeNames struct {
 	file      *lint.File
 	onFailure func(lint.Failure)
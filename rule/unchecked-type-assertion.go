@@ -1,9 +1,9 @@
 package rule
 
 import (
+	"errors"
 	"fmt"
 	"go/ast"
-	"sync"
 
 	"github.com/mgechev/revive/lint"
 )
@@ -15,24 +15,25 @@ const (
 
 // UncheckedTypeAssertionRule lints missing or ignored `ok`-value in danymic type casts.
 type UncheckedTypeAssertionRule struct {
-	sync.Mutex
+	configureOnce configureOnce
+
 	acceptIgnoredAssertionResult bool
-	configured                   bool
 }
 
-func (u *UncheckedTypeAssertionRule) configure(arguments lint.Arguments) {
-	u.Lock()
-	defer u.Unlock()
+// Configure validates the arguments of the rule and configures it.
+func (u *UncheckedTypeAssertionRule) Configure(arguments lint.Arguments) error {
+	return u.configureOnce.do(func() error { return u.configure(arguments) })
+}
 
-	if len(arguments) == 0 || u.configured {
-		return
+func (u *UncheckedTypeAssertionRule) configure(arguments lint.Arguments) error {
+	u.acceptIgnoredAssertionResult = false
+	if len(arguments) == 0 {
+		return nil
 	}
 
-	u.configured = true
-
 	args, ok := arguments[0].(map[string]any)
 	if !ok {
-		panic("Unable to get arguments. Expected object of key-value-pairs.")
+		return errors.New("unable to get arguments. Expected object of key-value-pairs")
 	}
 
 	for k, v := range args {
@@ -40,17 +41,18 @@ func (u *UncheckedTypeAssertionRule) configure(arguments lint.Arguments) {
 		case "acceptIgnoredAssertionResult":
 			u.acceptIgnoredAssertionResult, ok = v.(bool)
 			if !ok {
-				panic(fmt.Sprintf("Unable to parse argument '%s'. Expected boolean.", k))
+				return fmt.Errorf("unable to parse argument '%s'. Expected boolean", k)
 			}
 		default:
-			panic(fmt.Sprintf("Unknown argument: %s", k))
+			return fmt.Errorf("unknown argument: %s", k)
 		}
 	}
+	return nil
 }
 
 // Apply applies the rule to given file.
-func (u *UncheckedTypeAssertionRule) Apply(file *lint.File, args lint.Arguments) []lint.Failure {
-	u.configure(args)
+func (u *UncheckedTypeAssertionRule) Apply(file *lint.File, arguments lint.Arguments) []lint.Failure {
+	u.configureOnce.ensure(func() error { return u.configure(arguments) })
 
 	var failures []lint.Failure
 
@@ -71,6 +73,25 @@ func (*UncheckedTypeAssertionRule) Name() string {
 	return "unchecked-type-assertion"
 }
 
+// Metadata returns the description of the rule.
+func (*UncheckedTypeAssertionRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports type assertions whose success is not checked.",
+		Rationale:   "An unchecked type assertion panics when the value does not have the asserted type.",
+		Category:    "bugs",
+		Arguments: []lint.RuleArgument{
+			{Name: "acceptIgnoredAssertionResult", Type: "boolean", Description: "accepts the assertions whose check is assigned to _, given as [{ acceptIgnoredAssertionResult = true }]"},
+		},
+		Examples: []lint.RuleExample{{
+			Bad: `name := value.(string)`,
+			Good: `name, ok := value.(string)
+if !ok {
+	return errNotAString
+}`,
+		}},
+	}
+}
+
 type lintUnchekedTypeAssertion struct {
 	onFailure                        func(lint.Failure)
 	acceptIgnoredTypeAssertionResult bool
@@ -27,6 +27,23 @@ func (*UnconditionalRecursionRule) Name() string {
 	return "unconditional-recursion"
 }
 
+// Metadata returns the description of the rule.
+func (*UnconditionalRecursionRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports functions calling themselves unconditionally.",
+		Rationale:   "The recursion never ends, until the stack overflows.",
+		Category:    "bugs",
+		Examples: []lint.RuleExample{{
+			Bad: `func (u *User) Name() string {
+	return u.Name()
+}`,
+			Good: `func (u *User) Name() string {
+	return u.name
+}`,
+		}},
+	}
+}
+
 type funcDesc struct {
 	receiverID *ast.Ident
 	id         *ast.Ident
@@ -29,6 +29,19 @@ func (*UnexportedNamingRule) Name() string {
 	return "unexported-naming"
 }
 
+// Metadata returns the description of the rule.
+func (*UnexportedNamingRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports local symbols, such as variables and parameters, whose name starts with an uppercase letter.",
+		Rationale:   "An uppercase initial suggests the symbol is exported, which a local symbol cannot be.",
+		Category:    "naming",
+		Examples: []lint.RuleExample{{
+			Bad:  `func area(Width, Height int) int`,
+			Good: `func area(width, height int) int`,
+		}},
+	}
+}
+
 type unexportablenamingLinter struct {
 	onFailure func(lint.Failure)
 }
@@ -36,6 +36,24 @@ func (*UnexportedReturnRule) Name() string {
 	return "unexported-return"
 }
 
+// Metadata returns the description of the rule.
+func (*UnexportedReturnRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description:    "Reports exported functions and methods returning values of unexported types.",
+		Rationale:      "The callers cannot name the type of the results, e.g. to declare a variable or a field holding them.",
+		Category:       "api",
+		DefaultEnabled: true,
+		Examples: []lint.RuleExample{{
+			Bad: `type client struct{}
+
+func NewClient() *client`,
+			Good: `type Client struct{}
+
+func NewClient() *Client`,
+		}},
+	}
+}
+
 type lintUnexportedReturn struct {
 	file      *lint.File
 	fileAst   *ast.File
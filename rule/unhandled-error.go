@@ -1,50 +1,54 @@
 package rule
 
 import (
+	"errors"
 	"fmt"
 	"go/ast"
 	"go/types"
 	"regexp"
 	"strings"
-	"sync"
 
 	"github.com/mgechev/revive/lint"
 )
 
 // UnhandledErrorRule lints given else constructs.
 type UnhandledErrorRule struct {
+	configureOnce configureOnce
+
 	ignoreList []*regexp.Regexp
-	sync.Mutex
 }
 
-func (r *UnhandledErrorRule) configure(arguments lint.Arguments) {
-	r.Lock()
-	if r.ignoreList == nil {
-		for _, arg := range arguments {
-			argStr, ok := arg.(string)
-			if !ok {
-				panic(fmt.Sprintf("Invalid argument to the unhandled-error rule. Expecting a string, got %T", arg))
-			}
+// Configure validates the arguments of the rule and configures it.
+func (r *UnhandledErrorRule) Configure(arguments lint.Arguments) error {
+	return r.configureOnce.do(func() error { return r.configure(arguments) })
+}
 
-			argStr = strings.Trim(argStr, " ")
-			if argStr == "" {
-				panic("Invalid argument to the unhandled-error rule, expected regular expression must not be empty.")
-			}
+func (r *UnhandledErrorRule) configure(arguments lint.Arguments) error {
+	r.ignoreList = nil
+	for _, arg := range arguments {
+		argStr, ok := arg.(string)
+		if !ok {
+			return fmt.Errorf("invalid argument to the unhandled-error rule. Expecting a string, got %T", arg)
+		}
 
-			exp, err := regexp.Compile(argStr)
-			if err != nil {
-				panic(fmt.Sprintf("Invalid argument to the unhandled-error rule: regexp %q does not compile: %v", argStr, err))
-			}
+		argStr = strings.Trim(argStr, " ")
+		if argStr == "" {
+			return errors.New("invalid argument to the unhandled-error rule, expected regular expression must not be empty")
+		}
 
-			r.ignoreList = append(r.ignoreList, exp)
+		exp, err := regexp.Compile(argStr)
+		if err != nil {
+			return fmt.Errorf("invalid argument to the unhandled-error rule: regexp %q does not compile: %w", argStr, err)
 		}
+
+		r.ignoreList = append(r.ignoreList, exp)
 	}
-	r.Unlock()
+	return nil
 }
 
 // Apply applies the rule to given file.
-func (r *UnhandledErrorRule) Apply(file *lint.File, args lint.Arguments) []lint.Failure {
-	r.configure(args)
+func (r *UnhandledErrorRule) Apply(file *lint.File, arguments lint.Arguments) []lint.Failure {
+	r.configureOnce.ensure(func() error { return r.configure(arguments) })
 
 	var failures []lint.Failure
 
@@ -67,6 +71,24 @@ func (*UnhandledErrorRule) Name() string {
 	return "unhandled-error"
 }
 
+// Metadata returns the description of the rule.
+func (*UnhandledErrorRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports calls whose error result is ignored.",
+		Rationale:   "An ignored error hides a failure, to be handled or explicitly discarded.",
+		Category:    "errors",
+		Arguments: []lint.RuleArgument{
+			{Name: "ignore", Type: "list of strings", Description: "regular expressions of the functions whose errors may be ignored, e.g. \"fmt\\\\.Print.*\""},
+		},
+		Examples: []lint.RuleExample{{
+			Bad: `os.Remove(path)`,
+			Good: `if err := os.Remove(path); err != nil {
+	return err
+}`,
+		}},
+	}
+}
+
 type lintUnhandledErrors struct {
 	ignoreList []*regexp.Regexp
 	pkg        *lint.Package
@@ -27,6 +27,24 @@ func (*UnnecessaryStmtRule) Name() string {
 	return "unnecessary-stmt"
 }
 
+// Metadata returns the description of the rule.
+func (*UnnecessaryStmtRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports statements that can be simplified, such as switches with a single case or breaks ending a case.",
+		Rationale:   "The simpler statement is easier to read.",
+		Category:    "style",
+		Examples: []lint.RuleExample{{
+			Bad: `switch {
+case ok:
+	process()
+}`,
+			Good: `if ok {
+	process()
+}`,
+		}},
+	}
+}
+
 type lintUnnecessaryStmtRule struct {
 	onFailure func(lint.Failure)
 }
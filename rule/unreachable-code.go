@@ -46,6 +46,22 @@ func (*UnreachableCodeRule) Name() string {
 	return "unreachable-code"
 }
 
+// Metadata returns the description of the rule.
+func (*UnreachableCodeRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description:    "Reports code following a statement that never continues, such as return, panic or os.Exit.",
+		Rationale:      "Code that can never run is dead, or the sign of a bug.",
+		Category:       "logic",
+		DefaultEnabled: true,
+		Examples: []lint.RuleExample{{
+			Bad: `return nil
+log.Println("done")`,
+			Good: `log.Println("done")
+return nil`,
+		}},
+	}
+}
+
 type lintUnreachableCode struct {
 	onFailure          func(lint.Failure)
 	branchingFunctions map[string]map[string]bool
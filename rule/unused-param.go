@@ -4,28 +4,26 @@ import (
 	"fmt"
 	"go/ast"
 	"regexp"
-	"sync"
 
 	"github.com/mgechev/revive/lint"
 )
 
 // UnusedParamRule lints unused params in functions.
 type UnusedParamRule struct {
-	configured bool
+	configureOnce configureOnce
+
 	// regex to check if some name is valid for unused parameter, "^_$" by default
 	allowRegex *regexp.Regexp
 	failureMsg string
-	sync.Mutex
 }
 
-func (r *UnusedParamRule) configure(args lint.Arguments) {
-	r.Lock()
-	defer r.Unlock()
+// Configure validates the arguments of the rule and configures it.
+func (r *UnusedParamRule) Configure(arguments lint.Arguments) error {
+	return r.configureOnce.do(func() error { return r.configure(arguments) })
+}
 
-	if r.configured {
-		return
-	}
-	r.configured = true
+func (r *UnusedParamRule) configure(args lint.Arguments) error {
+	r.failureMsg = ""
 
 	// while by default args is an array, i think it's good to provide structures inside it by default, not arrays or primitives
 	// it's more compatible to JSON nature of configurations
@@ -35,30 +33,35 @@ func (r *UnusedParamRule) configure(args lint.Arguments) {
 		r.failureMsg = "parameter '%s' seems to be unused, consider removing or renaming it as _"
 	} else {
 		// Arguments = [{}]
-		options := args[0].(map[string]any)
+		options, ok := args[0].(map[string]any)
+		if !ok {
+			return fmt.Errorf("error configuring %s rule: expecting a k,v map, got %T", r.Name(), args[0])
+		}
 		// Arguments = [{allowedRegex="^_"}]
 
 		if allowedRegexParam, ok := options["allowRegex"]; ok {
 			allowedRegexStr, ok = allowedRegexParam.(string)
 			if !ok {
-				panic(fmt.Errorf("error configuring %s rule: allowedRegex is not string but [%T]", r.Name(), allowedRegexParam))
+				return fmt.Errorf("error configuring %s rule: allowedRegex is not string but [%T]", r.Name(), allowedRegexParam)
 			}
 		}
 	}
 	var err error
 	r.allowRegex, err = regexp.Compile(allowedRegexStr)
 	if err != nil {
-		panic(fmt.Errorf("error configuring %s rule: allowedRegex is not valid regex [%s]: %v", r.Name(), allowedRegexStr, err))
+		return fmt.Errorf("error configuring %s rule: allowedRegex is not valid regex [%s]: %w", r.Name(), allowedRegexStr, err)
 	}
 
 	if r.failureMsg == "" {
 		r.failureMsg = "parameter '%s' seems to be unused, consider removing or renaming it to match " + r.allowRegex.String()
 	}
+	return nil
 }
 
 // Apply applies the rule to given file.
-func (r *UnusedParamRule) Apply(file *lint.File, args lint.Arguments) []lint.Failure {
-	r.configure(args)
+func (r *UnusedParamRule) Apply(file *lint.File, arguments lint.Arguments) []lint.Failure {
+	r.configureOnce.ensure(func() error { return r.configure(arguments) })
+
 	var failures []lint.Failure
 
 	onFailure := func(failure lint.Failure) {
@@ -80,6 +83,27 @@ func (*UnusedParamRule) Name() string {
 	return "unused-parameter"
 }
 
+// Metadata returns the description of the rule.
+func (*UnusedParamRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description:    "Reports function parameters that are not used.",
+		Rationale:      "An unused parameter is the sign of an unfinished refactoring or of a bug. A parameter imposed by the signature, e.g. of an interface method, can be named _.",
+		Category:       "logic",
+		DefaultEnabled: true,
+		Arguments: []lint.RuleArgument{
+			{Name: "allowRegex", Type: "string", Description: "regular expression of the names of the parameters allowed to be unused, besides _, given as [{ allowRegex = \"...\" }]"},
+		},
+		Examples: []lint.RuleExample{{
+			Bad: `func greet(name string, age int) string {
+	return "hello " + name
+}`,
+			Good: `func greet(name string) string {
+	return "hello " + name
+}`,
+		}},
+	}
+}
+
 type lintUnusedParamRule struct {
 	onFailure  func(lint.Failure)
 	allowRegex *regexp.Regexp
@@ -4,28 +4,26 @@ import (
 	"fmt"
 	"go/ast"
 	"regexp"
-	"sync"
 
 	"github.com/mgechev/revive/lint"
 )
 
 // UnusedReceiverRule lints unused params in functions.
 type UnusedReceiverRule struct {
-	configured bool
+	configureOnce configureOnce
+
 	// regex to check if some name is valid for unused parameter, "^_$" by default
 	allowRegex *regexp.Regexp
 	failureMsg string
-	sync.Mutex
 }
 
-func (r *UnusedReceiverRule) configure(args lint.Arguments) {
-	r.Lock()
-	defer r.Unlock()
+// Configure validates the arguments of the rule and configures it.
+func (r *UnusedReceiverRule) Configure(arguments lint.Arguments) error {
+	return r.configureOnce.do(func() error { return r.configure(arguments) })
+}
 
-	if r.configured {
-		return
-	}
-	r.configured = true
+func (r *UnusedReceiverRule) configure(args lint.Arguments) error {
+	r.failureMsg = ""
 
 	// while by default args is an array, i think it's good to provide structures inside it by default, not arrays or primitives
 	// it's more compatible to JSON nature of configurations
@@ -35,29 +33,34 @@ func (r *UnusedReceiverRule) configure(args lint.Arguments) {
 		r.failureMsg = "method receiver '%s' is not referenced in method's body, consider removing or renaming it as _"
 	} else {
 		// Arguments = [{}]
-		options := args[0].(map[string]any)
+		options, ok := args[0].(map[string]any)
+		if !ok {
+			return fmt.Errorf("error configuring [unused-receiver] rule: expecting a k,v map, got %T", args[0])
+		}
 		// Arguments = [{allowedRegex="^_"}]
 
 		if allowedRegexParam, ok := options["allowRegex"]; ok {
 			allowedRegexStr, ok = allowedRegexParam.(string)
 			if !ok {
-				panic(fmt.Errorf("error configuring [unused-receiver] rule: allowedRegex is not string but [%T]", allowedRegexParam))
+				return fmt.Errorf("error configuring [unused-receiver] rule: allowedRegex is not string but [%T]", allowedRegexParam)
 			}
 		}
 	}
 	var err error
 	r.allowRegex, err = regexp.Compile(allowedRegexStr)
 	if err != nil {
-		panic(fmt.Errorf("error configuring [unused-receiver] rule: allowedRegex is not valid regex [%s]: %v", allowedRegexStr, err))
+		return fmt.Errorf("error configuring [unused-receiver] rule: allowedRegex is not valid regex [%s]: %w", allowedRegexStr, err)
 	}
 	if r.failureMsg == "" {
 		r.failureMsg = "method receiver '%s' is not referenced in method's body, consider removing or renaming it to match " + r.allowRegex.String()
 	}
+	return nil
 }
 
 // Apply applies the rule to given file.
-func (r *UnusedReceiverRule) Apply(file *lint.File, args lint.Arguments) []lint.Failure {
-	r.configure(args)
+func (r *UnusedReceiverRule) Apply(file *lint.File, arguments lint.Arguments) []lint.Failure {
+	r.configureOnce.ensure(func() error { return r.configure(arguments) })
+
 	var failures []lint.Failure
 
 	onFailure := func(failure lint.Failure) {
@@ -80,6 +83,22 @@ func (*UnusedReceiverRule) Name() string {
 	return "unused-receiver"
 }
 
+// Metadata returns the description of the rule.
+func (*UnusedReceiverRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports method receivers not used by their method.",
+		Rationale:   "An unused receiver can be named _ or omitted, or is the sign of an unfinished change.",
+		Category:    "style",
+		Arguments: []lint.RuleArgument{
+			{Name: "allowRegex", Type: "string", Description: "regular expression of the names allowed for unused receivers besides _, given as [{ allowRegex = \"...\" }]"},
+		},
+		Examples: []lint.RuleExample{{
+			Bad:  `func (s *Server) Name() string { return "server" }`,
+			Good: `func (*Server) Name() string { return "server" }`,
+		}},
+	}
+}
+
 type lintUnusedReceiverRule struct {
 	onFailure  func(lint.Failure)
 	allowRegex *regexp.Regexp
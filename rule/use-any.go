@@ -14,6 +14,7 @@ func (*UseAnyRule) Apply(file *lint.File, _ lint.Arguments) []lint.Failure {
 	var failures []lint.Failure
 
 	walker := lintUseAny{
+		file: file,
 		onFailure: func(failure lint.Failure) {
 			failures = append(failures, failure)
 		},
@@ -29,7 +30,22 @@ func (*UseAnyRule) Name() string {
 	return "use-any"
 }
 
+// Metadata returns the description of the rule.
+func (*UseAnyRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description:  "Reports uses of interface{}, that can be replaced by its alias any.",
+		Rationale:    "Since Go 1.18, any is the shorter and idiomatic way to write the empty interface.",
+		Category:     "style",
+		MinGoVersion: "1.18",
+		Examples: []lint.RuleExample{{
+			Bad:  `func print(v interface{})`,
+			Good: `func print(v any)`,
+		}},
+	}
+}
+
 type lintUseAny struct {
+	file      *lint.File
 	onFailure func(lint.Failure)
 }
 
@@ -48,6 +64,7 @@ func (w lintUseAny) Visit(n ast.Node) ast.Visitor {
 		Confidence: 1,
 		Category:   "naming",
 		Failure:    "since GO 1.18 'interface{}' can be replaced by 'any'",
+		Edits:      []lint.Edit{lint.ToEdit(it.Pos(), it.End(), "any", w.file)},
 	})
 
 	return w
@@ -29,6 +29,26 @@ func (*UselessBreak) Name() string {
 	return "useless-break"
 }
 
+// Metadata returns the description of the rule.
+func (*UselessBreak) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports break statements ending the cases of switch and select statements.",
+		Rationale:   "A case ends without falling through the next one, the break does nothing. In a loop, it may be meant to break the loop.",
+		Category:    "logic",
+		Examples: []lint.RuleExample{{
+			Bad: `switch kind {
+case "a":
+	handleA()
+	break
+}`,
+			Good: `switch kind {
+case "a":
+	handleA()
+}`,
+		}},
+	}
+}
+
 type lintUselessBreak struct {
 	onFailure  func(lint.Failure)
 	inLoopBody bool
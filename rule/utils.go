@@ -9,6 +9,7 @@ import (
 	"go/types"
 	"regexp"
 	"strings"
+	"sync"
 
 	"github.com/mgechev/revive/lint"
 )
@@ -160,9 +161,41 @@ func gofmt(x any) string {
 }
 
 // checkNumberOfArguments fails if the given number of arguments is not, at least, the expected one
-func checkNumberOfArguments(expected int, args lint.Arguments, ruleName string) {
+func checkNumberOfArguments(expected int, args lint.Arguments, ruleName string) error {
 	if len(args) < expected {
-		panic(fmt.Sprintf("not enough arguments for %s rule, expected %d, got %d. Please check the rule's documentation", ruleName, expected, len(args)))
+		return fmt.Errorf("not enough arguments for %s rule, expected %d, got %d. Please check the rule's documentation", ruleName, expected, len(args))
+	}
+	return nil
+}
+
+// configureOnce records whether a configurable rule has been configured.
+// Rules configured by config.GetLintingRules are configured before linting,
+// the other ones, e.g. rules passed directly to lint.Linter, are configured
+// on their first application with the arguments passed to Apply.
+type configureOnce struct {
+	mu         sync.Mutex
+	configured bool
+}
+
+// do configures the rule with configure, and records it as configured.
+func (c *configureOnce) do(configure func() error) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.configured = true
+	return configure()
+}
+
+// ensure configures the rule with configure unless it has already been configured.
+// As for rules that are not configured before linting, invalid arguments panic.
+func (c *configureOnce) ensure(configure func() error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.configured {
+		return
+	}
+	c.configured = true
+	if err := configure(); err != nil {
+		panic(err.Error())
 	}
 }
 
@@ -36,6 +36,22 @@ func (*VarDeclarationsRule) Name() string {
 	return "var-declaration"
 }
 
+// Metadata returns the description of the rule.
+func (*VarDeclarationsRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description:    "Reports variable declarations with a redundant type or an explicit zero value.",
+		Rationale:      "The type of a variable is inferred from its initialization, and a variable declared without initialization holds the zero value of its type: the redundant parts only make the declaration longer.",
+		Category:       "style",
+		DefaultEnabled: true,
+		Examples: []lint.RuleExample{{
+			Bad: `var count int = 0
+var name string = user.Name()`,
+			Good: `var count int
+var name = user.Name()`,
+		}},
+	}
+}
+
 type lintVarDeclarations struct {
 	fileAst   *ast.File
 	file      *lint.File
@@ -77,6 +93,7 @@ func (w *lintVarDeclarations) Visit(node ast.Node) ast.Visitor {
 				Node:       rhs,
 				Category:   "zero-value",
 				Failure:    fmt.Sprintf("should drop = %s from declaration of var %s; it is the zero value", w.file.Render(rhs), v.Names[0]),
+				Edits:      []lint.Edit{lint.ToEdit(v.Type.End(), rhs.End(), "", w.file)},
 			})
 			return nil
 		}
@@ -113,6 +130,7 @@ func (w *lintVarDeclarations) Visit(node ast.Node) ast.Visitor {
 			Confidence: 0.8,
 			Node:       v.Type,
 			Failure:    fmt.Sprintf("should omit type %s from declaration of var %s; it will be inferred from the right-hand side", w.file.Render(v.Type), v.Names[0]),
+			Edits:      []lint.Edit{lint.ToEdit(v.Names[0].End(), v.Type.End(), "", w.file)},
 		})
 		return nil
 	}
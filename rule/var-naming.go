@@ -6,7 +6,6 @@ import (
 	"go/token"
 	"regexp"
 	"strings"
-	"sync"
 
 	"github.com/mgechev/revive/lint"
 )
@@ -18,28 +17,36 @@ var upperCaseConstRE = regexp.MustCompile(`^_?[A-Z][A-Z\d]*(_[A-Z\d]+)*$`)
 
 // VarNamingRule lints given else constructs.
 type VarNamingRule struct {
-	configured            bool
+	configureOnce configureOnce
+
 	allowlist             []string
 	blocklist             []string
 	upperCaseConst        bool // if true - allows to use UPPER_SOME_NAMES for constants
 	skipPackageNameChecks bool
-	sync.Mutex
 }
 
-func (r *VarNamingRule) configure(arguments lint.Arguments) {
-	r.Lock()
-	defer r.Unlock()
-	if r.configured {
-		return
-	}
+// Configure validates the arguments of the rule and configures it.
+func (r *VarNamingRule) Configure(arguments lint.Arguments) error {
+	return r.configureOnce.do(func() error { return r.configure(arguments) })
+}
+
+func (r *VarNamingRule) configure(arguments lint.Arguments) error {
+	r.allowlist, r.blocklist = nil, nil
+	r.upperCaseConst, r.skipPackageNameChecks = false, false
 
-	r.configured = true
+	var err error
 	if len(arguments) >= 1 {
-		r.allowlist = getList(arguments[0], "allowlist")
+		r.allowlist, err = getList(arguments[0], "allowlist")
+		if err != nil {
+			return err
+		}
 	}
 
 	if len(arguments) >= 2 {
-		r.blocklist = getList(arguments[1], "blocklist")
+		r.blocklist, err = getList(arguments[1], "blocklist")
+		if err != nil {
+			return err
+		}
 	}
 
 	if len(arguments) >= 3 {
@@ -47,18 +54,19 @@ func (r *VarNamingRule) configure(arguments lint.Arguments) {
 		thirdArgument := arguments[2]
 		asSlice, ok := thirdArgument.([]any)
 		if !ok {
-			panic(fmt.Sprintf("Invalid third argument to the var-naming rule. Expecting a %s of type slice, got %T", "options", arguments[2]))
+			return fmt.Errorf("invalid third argument to the var-naming rule. Expecting a %s of type slice, got %T", "options", arguments[2])
 		}
 		if len(asSlice) != 1 {
-			panic(fmt.Sprintf("Invalid third argument to the var-naming rule. Expecting a %s of type slice, of len==1, but %d", "options", len(asSlice)))
+			return fmt.Errorf("invalid third argument to the var-naming rule. Expecting a %s of type slice, of len==1, but %d", "options", len(asSlice))
 		}
 		args, ok := asSlice[0].(map[string]any)
 		if !ok {
-			panic(fmt.Sprintf("Invalid third argument to the var-naming rule. Expecting a %s of type slice, of len==1, with map, but %T", "options", asSlice[0]))
+			return fmt.Errorf("invalid third argument to the var-naming rule. Expecting a %s of type slice, of len==1, with map, but %T", "options", asSlice[0])
 		}
 		r.upperCaseConst = fmt.Sprint(args["upperCaseConst"]) == "true"
 		r.skipPackageNameChecks = fmt.Sprint(args["skipPackageNameChecks"]) == "true"
 	}
+	return nil
 }
 
 func (r *VarNamingRule) applyPackageCheckRules(walker *lintNames) {
@@ -84,7 +92,7 @@ func (r *VarNamingRule) applyPackageCheckRules(walker *lintNames) {
 
 // Apply applies the rule to given file.
 func (r *VarNamingRule) Apply(file *lint.File, arguments lint.Arguments) []lint.Failure {
-	r.configure(arguments)
+	r.configureOnce.ensure(func() error { return r.configure(arguments) })
 
 	var failures []lint.Failure
 
@@ -115,6 +123,27 @@ func (*VarNamingRule) Name() string {
 	return "var-naming"
 }
 
+// Metadata returns the description of the rule.
+func (*VarNamingRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description:    "Reports names not following the Go conventions: mixed caps, initialisms of a consistent case and package names.",
+		Rationale:      "Names following the same conventions make code easier to read across Go code bases.",
+		Category:       "naming",
+		DefaultEnabled: true,
+		Arguments: []lint.RuleArgument{
+			{Name: "allowlist", Type: "list of strings", Description: "initialisms allowed in any case"},
+			{Name: "blocklist", Type: "list of strings", Description: "additional initialisms"},
+			{Name: "options", Type: "list of one table", Description: "upperCaseConst = true allows UPPER_CASE constants, skipPackageNameChecks = true skips the checks of the package names"},
+		},
+		Examples: []lint.RuleExample{{
+			Bad: `var userId int
+var max_size = 10`,
+			Good: `var userID int
+var maxSize = 10`,
+		}},
+	}
+}
+
 func (w *lintNames) checkList(fl *ast.FieldList, thing string) {
 	if fl == nil {
 		return
@@ -264,18 +293,18 @@ func (w *lintNames) Visit(n ast.Node) ast.Visitor {
 	return w
 }
 
-func getList(arg any, argName string) []string {
+func getList(arg any, argName string) ([]string, error) {
 	temp, ok := arg.([]any)
 	if !ok {
-		panic(fmt.Sprintf("Invalid argument to the var-naming rule. Expecting a %s of type slice with initialisms, got %T", argName, arg))
+		return nil, fmt.Errorf("invalid argument to the var-naming rule. Expecting a %s of type slice with initialisms, got %T", argName, arg)
 	}
 	var list []string
 	for _, v := range temp {
-		if val, ok := v.(string); ok {
-			list = append(list, val)
-		} else {
-			panic(fmt.Sprintf("Invalid %s values of the var-naming rule. Expecting slice of strings but got element of type %T", val, arg))
+		val, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid %s values of the var-naming rule. Expecting slice of strings but got element of type %T", argName, v)
 		}
+		list = append(list, val)
 	}
-	return list
+	return list, nil
 }
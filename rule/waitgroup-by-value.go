@@ -27,6 +27,19 @@ func (*WaitGroupByValueRule) Name() string {
 	return "waitgroup-by-value"
 }
 
+// Metadata returns the description of the rule.
+func (*WaitGroupByValueRule) Metadata() lint.RuleInfo {
+	return lint.RuleInfo{
+		Description: "Reports functions taking a sync.WaitGroup parameter by value.",
+		Rationale:   "The function gets a copy of the wait group, calling Done on it does not affect the original one.",
+		Category:    "bugs",
+		Examples: []lint.RuleExample{{
+			Bad:  `func worker(wg sync.WaitGroup)`,
+			Good: `func worker(wg *sync.WaitGroup)`,
+		}},
+	}
+}
+
 type lintWaitGroupByValueRule struct {
 	onFailure func(lint.Failure)
 }
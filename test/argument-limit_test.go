@@ -12,3 +12,36 @@ func TestArgumentLimit(t *testing.T) {
 		Arguments: []any{int64(3)},
 	})
 }
+
+func TestArgumentLimitWithoutConfigure(t *testing.T) {
+	const src = `package p
+
+func f(a, b int) {}
+`
+	for name, tc := range map[string]struct {
+		arguments lint.Arguments
+		want      int
+	}{
+		"default": {want: 0},
+		"apply":   {arguments: lint.Arguments{int64(1)}, want: 1},
+	} {
+		t.Run(name, func(t *testing.T) {
+			r := &rule.ArgumentsLimitRule{}
+			l := lint.New(func(string) ([]byte, error) { return []byte(src), nil }, 0)
+			failures, err := l.Lint([][]string{{"p.go"}}, []lint.Rule{r}, lint.Config{
+				Rules: lint.RulesConfig{r.Name(): {Arguments: tc.arguments}},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := 0
+			for range failures {
+				got++
+			}
+			if got != tc.want {
+				t.Errorf("got %d failures, want %d", got, tc.want)
+			}
+		})
+	}
+}
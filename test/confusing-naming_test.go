@@ -9,4 +9,5 @@ import (
 // TestConfusingNaming rule.
 func TestConfusingNaming(t *testing.T) {
 	testRule(t, "confusing-naming1", &rule.ConfusingNamingRule{})
+	testPackageRule(t, []string{"confusing-naming1", "confusing-naming2"}, &rule.ConfusingNamingRule{})
 }
@@ -18,3 +18,7 @@ func TestModifiedAnnotations(t *testing.T) {
 func TestDisableNextLineAnnotations(t *testing.T) {
 	testRule(t, "disable-annotations3", &rule.VarNamingRule{}, &lint.RuleConfig{})
 }
+
+func TestDisableDeclAnnotations(t *testing.T) {
+	testRule(t, "disable-annotations-decl", &rule.VarNamingRule{}, &lint.RuleConfig{})
+}
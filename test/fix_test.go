@@ -0,0 +1,23 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/mgechev/revive/rule"
+)
+
+// TestFixIncrementDecrement tests the fixes of the increment-decrement rule.
+func TestFixIncrementDecrement(t *testing.T) {
+	testFix(t, "increment-decrement", &rule.IncrementDecrementRule{})
+}
+
+// TestFixVarDeclaration tests the fixes of the var-declaration rule.
+func TestFixVarDeclaration(t *testing.T) {
+	testFix(t, "var-declaration", &rule.VarDeclarationsRule{})
+}
+
+// TestFixErrorf tests the fixes of the errorf rule.
+func TestFixErrorf(t *testing.T) {
+	testFix(t, "errorf", &rule.ErrorfRule{})
+	testFix(t, "errorf-unused-import", &rule.ErrorfRule{})
+}
@@ -8,4 +8,5 @@ import (
 // TestRedundantImportAlias rule.
 func TestRedundantImportAlias(t *testing.T) {
 	testRule(t, "redundant-import-alias", &rule.RedundantImportAlias{})
+	testFix(t, "redundant-import-alias", &rule.RedundantImportAlias{})
 }
@@ -12,4 +12,5 @@ import (
 func TestSuperfluousElse(t *testing.T) {
 	testRule(t, "superfluous-else", &rule.SuperfluousElseRule{})
 	testRule(t, "superfluous-else-scope", &rule.SuperfluousElseRule{}, &lint.RuleConfig{Arguments: []any{ifelse.PreserveScope}})
+	testFix(t, "superfluous-else", &rule.SuperfluousElseRule{})
 }
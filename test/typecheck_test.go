@@ -0,0 +1,67 @@
+package test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/mgechev/revive/lint"
+	"github.com/mgechev/revive/rule"
+)
+
+func lintTypeCheckModule(t *testing.T, typeCheck lint.TypeCheckConfig) []lint.Failure {
+	t.Helper()
+
+	l := lint.New(func(file string) ([]byte, error) {
+		return os.ReadFile("../testdata/" + file)
+	}, 0)
+
+	ps, err := l.Lint([][]string{{"typecheck-module.go"}}, []lint.Rule{&rule.UnhandledErrorRule{}}, lint.Config{
+		Rules:     map[string]lint.RuleConfig{},
+		TypeCheck: typeCheck,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	failures := []lint.Failure{}
+	for f := range ps {
+		failures = append(failures, f)
+	}
+	return failures
+}
+
+func failureMessages(failures []lint.Failure) map[string]bool {
+	result := map[string]bool{}
+	for _, f := range failures {
+		result[f.Failure] = true
+	}
+	return result
+}
+
+// TestTypeCheckDefaultImporter shows the default importer does not resolve module imports.
+func TestTypeCheckDefaultImporter(t *testing.T) {
+	failures := lintTypeCheckModule(t, lint.TypeCheckConfig{})
+	if len(failures) != 0 {
+		t.Fatalf("Expected no failures with the default importer, got %v", failureMessages(failures))
+	}
+}
+
+// TestTypeCheckModuleImporter tests module-aware type checking.
+func TestTypeCheckModuleImporter(t *testing.T) {
+	failures := lintTypeCheckModule(t, lint.TypeCheckConfig{Importer: lint.ImporterModule, ReportErrors: true})
+
+	got := failureMessages(failures)
+	want := []string{
+		"Unhandled error in call to function github.com/mgechev/revive/lint.ParseFileFilter",
+		"Unhandled error in call to function github.com/pkg/errors.Wrap",
+		"undefined: undefinedIdentifier",
+	}
+	for _, msg := range want {
+		if !got[msg] {
+			t.Errorf("Expected failure %q, got %v", msg, got)
+		}
+	}
+	if len(failures) != len(want) {
+		t.Errorf("Expected %d failures, got %v", len(want), got)
+	}
+}
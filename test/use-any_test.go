@@ -8,4 +8,5 @@ import (
 
 func TestUseAny(t *testing.T) {
 	testRule(t, "use-any", &rule.UseAnyRule{})
+	testFix(t, "use-any", &rule.UseAnyRule{})
 }
@@ -40,7 +40,124 @@ func testRule(t *testing.T, filename string, rule lint.Rule, config ...*lint.Rul
 	assertFailures(t, baseDir, stat, src, []lint.Rule{rule}, c)
 }
 
+// testPackageRule lints the given files of testdata as a single package
+// and checks the failures against the instructions found in each file.
+func testPackageRule(t *testing.T, filenames []string, rule lint.Rule, config ...*lint.RuleConfig) {
+	t.Helper()
+
+	baseDir := "../testdata/"
+	c := map[string]lint.RuleConfig{}
+	if config != nil {
+		c[rule.Name()] = *config[0]
+	}
+
+	files := make([]string, len(filenames))
+	for i, filename := range filenames {
+		files[i] = filename + ".go"
+	}
+	configureRules(t, []lint.Rule{rule}, c)
+
+	l := lint.New(func(file string) ([]byte, error) {
+		return os.ReadFile(baseDir + file)
+	}, 0)
+
+	ps, err := l.Lint([][]string{files}, []lint.Rule{rule}, lint.Config{
+		Rules: c,
+	})
+	if err != nil {
+		t.Fatalf("Linting %v: %v", files, err)
+	}
+
+	failures := []lint.Failure{}
+	for f := range ps {
+		failures = append(failures, f)
+	}
+
+	for _, file := range files {
+		src, err := os.ReadFile(baseDir + file)
+		if err != nil {
+			t.Fatalf("Bad filename path in test for %s: %v", rule.Name(), err)
+		}
+
+		for _, in := range parseInstructions(t, file, src) {
+			ok := false
+			for i, p := range failures {
+				if p.GetFilename() != file || p.Position.Start.Line != in.Line || p.Failure != in.Match {
+					continue
+				}
+
+				copy(failures[i:], failures[i+1:])
+				failures = failures[:len(failures)-1]
+				ok = true
+				break
+			}
+			if !ok {
+				t.Errorf("Lint failed at %s:%d; /%v/ did not match", file, in.Line, in.Match)
+			}
+		}
+	}
+	for _, p := range failures {
+		t.Errorf("Unexpected problem at %s:%d: %v", p.GetFilename(), p.Position.Start.Line, p.Failure)
+	}
+}
+
+// testFix lints the given file from testdata/fix with the rule, applies the
+// suggested fixes and checks the result against the corresponding golden file.
+func testFix(t *testing.T, filename string, rule lint.Rule) {
+	t.Helper()
+
+	baseDir := "../testdata/fix/"
+	filename = filename + ".go"
+	src, err := os.ReadFile(baseDir + filename)
+	if err != nil {
+		t.Fatalf("Bad filename path in test for %s: %v", rule.Name(), err)
+	}
+	want, err := os.ReadFile(baseDir + filename + ".golden")
+	if err != nil {
+		t.Fatalf("Cannot read golden file for %s: %v", rule.Name(), err)
+	}
+	configureRules(t, []lint.Rule{rule}, nil)
+
+	l := lint.New(func(file string) ([]byte, error) {
+		return os.ReadFile(baseDir + file)
+	}, 0)
+
+	ps, err := l.Lint([][]string{{filename}}, []lint.Rule{rule}, lint.Config{
+		Rules: map[string]lint.RuleConfig{},
+	})
+	if err != nil {
+		t.Fatalf("Linting %s: %v", filename, err)
+	}
+
+	failures := []lint.Failure{}
+	for f := range ps {
+		failures = append(failures, f)
+	}
+
+	got, _ := lint.ApplyFixes(src, failures)
+	if string(got) != string(want) {
+		t.Errorf("Fixing %s with %s, got:\n%s\nwant:\n%s", filename, rule.Name(), got, want)
+	}
+}
+
+// configureRules configures the configurable rules with the arguments of their configuration.
+func configureRules(t *testing.T, rules []lint.Rule, config map[string]lint.RuleConfig) {
+	t.Helper()
+
+	for _, r := range rules {
+		cr, ok := r.(lint.ConfigurableRule)
+		if !ok {
+			continue
+		}
+		if err := cr.Configure(config[r.Name()].Arguments); err != nil {
+			t.Fatalf("Cannot configure rule %s: %v", r.Name(), err)
+		}
+	}
+}
+
 func assertSuccess(t *testing.T, baseDir string, fi os.FileInfo, rules []lint.Rule, config map[string]lint.RuleConfig) error {
+	configureRules(t, rules, config)
+
 	l := lint.New(func(file string) ([]byte, error) {
 		return os.ReadFile(baseDir + file)
 	}, 0)
@@ -63,6 +180,8 @@ func assertSuccess(t *testing.T, baseDir string, fi os.FileInfo, rules []lint.Ru
 }
 
 func assertFailures(t *testing.T, baseDir string, fi os.FileInfo, src []byte, rules []lint.Rule, config map[string]lint.RuleConfig) error {
+	configureRules(t, rules, config)
+
 	l := lint.New(func(file string) ([]byte, error) {
 		return os.ReadFile(baseDir + file)
 	}, 0)
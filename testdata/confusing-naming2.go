@@ -3,5 +3,5 @@
 // Package pkg ...
 package pkg
 
-func aglobal() { // MATCH /Function 'aglobal' differs only by capitalization to other function in the same package/
+func aglobal() { // MATCH /Method 'aglobal' differs only by capitalization to function 'aGlobal' in confusing-naming1.go/
 }
@@ -0,0 +1,30 @@
+package fixtures
+
+//revive:disable-decl:var-naming
+func foo1() {
+	var invalid_name = 0
+}
+
+func foo2() {
+	var invalid_name = 0 // MATCH /don't use underscores in Go names; var invalid_name should be invalidName/
+}
+
+// some_type is not disabled as a whole.
+type some_type struct { // MATCH /don't use underscores in Go names; type some_type should be someType/
+	//revive:disable-decl:var-naming legacy field
+	invalid_field int
+	other_field   int // MATCH /don't use underscores in Go names; struct field other_field should be otherField/
+}
+
+// Function is disabled as a whole, including its parameters.
+//
+//revive:disable-decl:var-naming
+func foo3(invalid_param int) {
+	var invalid_name = 0
+}
+
+var (
+	//revive:disable-decl:var-naming
+	first_name = 0
+	last_name  = 0 // MATCH /don't use underscores in Go names; var last_name should be lastName/
+)
@@ -0,0 +1,10 @@
+package fixtures
+
+import (
+	"errors"
+	"fmt"
+)
+
+func f(x int) error {
+	return errors.New(fmt.Sprintf("invalid value %d", x))
+}
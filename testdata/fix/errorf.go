@@ -0,0 +1,20 @@
+package fixtures
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+var errSentinel = errors.New("sentinel")
+
+func f(x int) error {
+	if x > 0 {
+		return errors.New(fmt.Sprintf("invalid value %d", x))
+	}
+	return errSentinel
+}
+
+func TestF(t *testing.T) {
+	t.Error(fmt.Sprintf("not %s", "ok"))
+}
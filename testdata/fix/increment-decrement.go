@@ -0,0 +1,10 @@
+package fixtures
+
+func f() {
+	var i, j int
+	i += 1
+	j -= 1
+	i += 2
+	s := []int{1}
+	s[i] += 1
+}
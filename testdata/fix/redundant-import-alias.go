@@ -0,0 +1,13 @@
+package fixtures
+
+import (
+	context "context"
+	ast "go/ast"
+	parser "go/parser"
+	tok "go/token"
+)
+
+var _ = context.Background
+var _ ast.Node
+var _ = parser.ParseFile
+var _ tok.Pos
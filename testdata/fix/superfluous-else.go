@@ -0,0 +1,36 @@
+package fixtures
+
+import "fmt"
+
+func f(xs []int) {
+	for _, x := range xs {
+		if x > 10 {
+			continue
+		} else {
+			fmt.Println(x)
+			if x > 5 {
+				fmt.Println("big")
+			}
+		}
+	}
+}
+
+func g(xs []int) {
+	for _, x := range xs {
+		if x > 10 {
+			break
+		} else {
+			y := x * 2
+			fmt.Println(y)
+		}
+	}
+}
+
+func h(xs []int) {
+	for _, x := range xs {
+		if x > 10 {
+			continue
+		} else {
+		}
+	}
+}
@@ -0,0 +1,7 @@
+package fixtures
+
+var x interface{}
+
+func f(a interface{}, b []interface{}) map[string]interface{} {
+	return nil
+}
@@ -0,0 +1,13 @@
+package fixtures
+
+var a int = 0
+var b string = ""
+var c *int = nil
+var d string = "hello"
+var e int = 7
+
+func f() {
+	var g float64 = 0
+	var h bool = true
+	_, _ = g, h
+}
@@ -0,0 +1,12 @@
+package fixtures
+
+import (
+	"github.com/mgechev/revive/lint"
+	"github.com/pkg/errors"
+)
+
+func unhandled() {
+	lint.ParseFileFilter("*.go")
+	errors.Wrap(nil, "ignored")
+	_ = undefinedIdentifier
+}